@@ -0,0 +1,121 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Cgroup describes the cgroup a container's initial process is a member of.
+type Cgroup struct {
+	// Path of the cgroup, relative to the root of its cgroup hierarchy, such
+	// as "/system.slice/docker-1234.scope". Please note that the path is
+	// relative to the cgroup namespace of the process reading it.
+	Path string `json:"path"`
+	// Dir is the absolute directory of the cgroup in the cgroup filesystem,
+	// such as "/sys/fs/cgroup/system.slice/docker-1234.scope".
+	Dir string `json:"dir"`
+	// V2 is true for the cgroup v2 unified hierarchy, and false for a cgroup
+	// v1 controller hierarchy.
+	V2 bool `json:"v2,omitempty"`
+}
+
+// cgroupV1Controllers lists the cgroup v1 controllers in order of preference
+// when falling back to cgroup v1.
+var cgroupV1Controllers = []string{"memory", "cpu", "cpuacct", "pids"}
+
+// ReadCgroup returns the cgroup the process with the specified PID is a member
+// of, using the proc filesystem mounted at procfs, such as "/proc", and the
+// cgroup filesystem mounted at cgroupfs, such as "/sys/fs/cgroup"; see also
+// [ParseCgroup].
+func ReadCgroup(procfs, cgroupfs string, pid int) (*Cgroup, error) {
+	contents, err := os.ReadFile(filepath.Join(procfs, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cgroup of process with PID %d: %w", pid, err)
+	}
+	cgroup, err := ParseCgroup(contents, cgroupfs)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cgroup of process with PID %d: %w", pid, err)
+	}
+	return cgroup, nil
+}
+
+// ParseCgroup returns the cgroup from the contents of a "/proc/PID/cgroup"
+// file, resolving the cgroup's directory against the specified cgroup
+// filesystem mount, such as "/sys/fs/cgroup"; see also cgroups(7).
+//
+// On pure cgroup v2 systems there is only the unified hierarchy and thus
+// always a v2 cgroup is returned. On cgroup v1 and "hybrid" systems, the cgroup
+// of the first v1 hierarchy with a "memory", "cpu", "cpuacct", or "pids"
+// controller is returned instead, as the unified hierarchy of hybrid systems
+// usually lacks controllers. Only if there are no such v1 hierarchies, the
+// unified hierarchy of a hybrid system is used, with its directory resolved
+// against the "unified" subdirectory of the cgroup filesystem mount.
+func ParseCgroup(contents []byte, cgroupfs string) (*Cgroup, error) {
+	var unified string
+	hasUnified := false
+	v1 := map[string]string{}     // controller to path
+	v1dirs := map[string]string{} // controller to hierarchy directory name
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		// Lines are in the format "hierarchy-ID:controller-list:cgroup-path".
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			unified = fields[2]
+			hasUnified = true
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			v1[controller] = fields[2]
+			v1dirs[controller] = fields[1]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if hasUnified && len(v1) == 0 {
+		return &Cgroup{
+			Path: unified,
+			Dir:  filepath.Join(cgroupfs, unified),
+			V2:   true,
+		}, nil
+	}
+	for _, controller := range cgroupV1Controllers {
+		if path, ok := v1[controller]; ok {
+			return &Cgroup{
+				Path: path,
+				Dir:  filepath.Join(cgroupfs, v1dirs[controller], path),
+			}, nil
+		}
+	}
+	if hasUnified {
+		return &Cgroup{
+			Path: unified,
+			Dir:  filepath.Join(cgroupfs, "unified", unified),
+			V2:   true,
+		}, nil
+	}
+	return nil, errors.New("no suitable cgroup hierarchy")
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("cgroups", func() {
+
+	DescribeTable("parsing cgroup memberships",
+		func(contents string, expected *Cgroup) {
+			Expect(ParseCgroup([]byte(contents), "/sys/fs/cgroup")).To(Equal(expected))
+		},
+		Entry("cgroup v2", "0::/system.slice/docker-1234.scope\n",
+			&Cgroup{
+				Path: "/system.slice/docker-1234.scope",
+				Dir:  "/sys/fs/cgroup/system.slice/docker-1234.scope",
+				V2:   true,
+			}),
+		Entry("cgroup v1", "12:pids:/docker/1234\n5:cpu,cpuacct:/docker/1234\n4:memory:/docker/1234\n1:name=systemd:/docker/1234\n",
+			&Cgroup{
+				Path: "/docker/1234",
+				Dir:  "/sys/fs/cgroup/memory/docker/1234",
+			}),
+		Entry("cgroup v1 sans memory", "12:pids:/docker/1234\n5:cpu,cpuacct:/docker/1234\n",
+			&Cgroup{
+				Path: "/docker/1234",
+				Dir:  "/sys/fs/cgroup/cpu,cpuacct/docker/1234",
+			}),
+		Entry("hybrid", "4:memory:/docker/1234\n1:name=systemd:/docker/1234\n0::/docker/1234\n",
+			&Cgroup{
+				Path: "/docker/1234",
+				Dir:  "/sys/fs/cgroup/memory/docker/1234",
+			}),
+		Entry("hybrid sans v1 controllers", "1:name=systemd:/docker/1234\n0::/docker/1234\n",
+			&Cgroup{
+				Path: "/docker/1234",
+				Dir:  "/sys/fs/cgroup/unified/docker/1234",
+				V2:   true,
+			}),
+	)
+
+	It("rejects unsuitable cgroup memberships", func() {
+		Expect(ParseCgroup([]byte("1:name=systemd:/\n"), "/sys/fs/cgroup")).Error().To(HaveOccurred())
+		Expect(ParseCgroup([]byte("garbage\n"), "/sys/fs/cgroup")).Error().To(HaveOccurred())
+	})
+
+	It("reads the cgroup of a process", func() {
+		Expect(ReadCgroup("/proc", "/sys/fs/cgroup", os.Getpid())).To(
+			HaveField("Dir", HavePrefix("/sys/fs/cgroup")))
+		Expect(ReadCgroup("/proc", "/sys/fs/cgroup", 0)).Error().To(MatchError(
+			ContainSubstring("cannot read cgroup of process with PID 0")))
+	})
+
+	It("round-trips cgroups via JSON", func() {
+		c := &Container{ID: "1", Name: "furious_furuncle", Cgroup: &Cgroup{Path: "/foo", Dir: "/sys/fs/cgroup/foo", V2: true}}
+		j := Successful(json.Marshal(c))
+		Expect(j).To(MatchJSON(`{"id":"1","name":"furious_furuncle","pid":0,
+			"cgroup":{"path":"/foo","dir":"/sys/fs/cgroup/foo","v2":true}}`))
+		var c2 Container
+		Expect(json.Unmarshal(j, &c2)).To(Succeed())
+		Expect(c2.Cgroup).To(Equal(c.Cgroup))
+	})
+
+})
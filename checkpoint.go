@@ -0,0 +1,72 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveCheckpoint saves a consistent snapshot of the specified portfolio in its
+// JSON representation to the file at the specified path. The checkpoint file is
+// replaced atomically, so that readers never see a partially written
+// checkpoint, even when the process crashes while saving.
+func SaveCheckpoint(path string, pf *Portfolio) error {
+	data, err := json.Marshal(pf)
+	if err != nil {
+		return fmt.Errorf("cannot save portfolio checkpoint: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot save portfolio checkpoint: %w", err)
+	}
+	tmpname := f.Name()
+	defer func() {
+		_ = os.Remove(tmpname) // fails harmlessly after successful rename.
+	}()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot save portfolio checkpoint: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("cannot save portfolio checkpoint: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("cannot save portfolio checkpoint: %w", err)
+	}
+	if err := os.Rename(tmpname, path); err != nil {
+		return fmt.Errorf("cannot save portfolio checkpoint: %w", err)
+	}
+	return nil
+}
+
+// LoadCheckpoint returns a new Portfolio loaded from the checkpoint file at the
+// specified path, as previously saved using [SaveCheckpoint]. Similar to
+// [UnmarshalPortfolio], an optional unpacker restores container Rucksacks and
+// the new Portfolio is created using the specified options.
+func LoadCheckpoint(path string, unpacker RucksackUnpacker, opts ...PortfolioOption) (*Portfolio, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load portfolio checkpoint: %w", err)
+	}
+	pf, err := UnmarshalPortfolio(data, unpacker, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load portfolio checkpoint %q: %w", path, err)
+	}
+	return pf, nil
+}
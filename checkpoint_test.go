@@ -0,0 +1,69 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("portfolio checkpoints", func() {
+
+	It("saves and loads checkpoints", func() {
+		dir := GinkgoT().TempDir()
+		path := filepath.Join(dir, "portfolio.json")
+
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy", PID: 42,
+			Rucksack: &rucksack{Motto: "I'm not dead yet"}})
+		pf.Add(&Container{ID: "2", Name: "mad_moby", PID: 666, Paused: true})
+		Expect(SaveCheckpoint(path, pf)).To(Succeed())
+		Expect(SaveCheckpoint(path, pf)).To(Succeed())
+		Expect(os.ReadDir(dir)).To(HaveLen(1))
+
+		pf2 := Successful(LoadCheckpoint(path, RucksackUnpackerFunc(
+			func(cntr *Container, data json.RawMessage) error {
+				var r rucksack
+				if err := json.Unmarshal(data, &r); err != nil {
+					return err
+				}
+				cntr.Rucksack = &r
+				return nil
+			})))
+		Expect(pf2.Names()).To(ConsistOf("grumpy"))
+		Expect(pf2.Container("furious_furuncle")).To(HaveField("Rucksack", &rucksack{Motto: "I'm not dead yet"}))
+		Expect(pf2.ContainerByPID(666)).To(HaveField("Paused", true))
+	})
+
+	It("reports checkpoint errors", func() {
+		dir := GinkgoT().TempDir()
+		Expect(LoadCheckpoint(filepath.Join(dir, "missing.json"), nil)).Error().To(
+			MatchError(os.ErrNotExist))
+
+		path := filepath.Join(dir, "broken.json")
+		Expect(os.WriteFile(path, []byte("{"), 0o644)).To(Succeed())
+		Expect(LoadCheckpoint(path, nil)).Error().To(
+			MatchError(ContainSubstring("cannot load portfolio checkpoint")))
+
+		Expect(SaveCheckpoint(filepath.Join(dir, "missing", "portfolio.json"), NewPortfolio())).To(
+			MatchError(ContainSubstring("cannot save portfolio checkpoint")))
+	})
+
+})
@@ -16,6 +16,7 @@ package whalewatcher
 
 import (
 	"fmt"
+	"time"
 )
 
 // Container is a deliberately limited view on containers, dealing with only
@@ -33,7 +34,8 @@ import (
 //
 // The Rucksack supports storing additional application-specific container (and
 // container engine-specific) information; see also
-// [github.com/thediveo/whalewatcher/engineclient.RucksackPacker].
+// [github.com/thediveo/whalewatcher/engineclient.RucksackPacker]. Use
+// [RucksackOf] to retrieve a Rucksack of a specific type.
 type Container struct {
 	ID       string            // unique identifier of this container.
 	Name     string            // user-friendly name of this container.
@@ -41,9 +43,29 @@ type Container struct {
 	PID      int               // PID of container's initial ("ealdorman") process.
 	Project  string            // optional composer project name, or zero.
 	Paused   bool              // true if container is paused, false if running.
+	Health   string            // optional health status, such as "healthy"; zero if unknown or without health check.
 	Rucksack any               // optional additional application-specific container information.
+
+	// StartedAt optionally is the time when the container was started, if
+	// known; otherwise, it is zero.
+	StartedAt time.Time
+
+	// Namespaces optionally lists the Linux namespaces of the container's
+	// initial process, if known; see also [ReadNamespaces].
+	Namespaces Namespaces
+	// Cgroup optionally describes the cgroup of the container's initial
+	// process, if known; see also [ReadCgroup].
+	Cgroup *Cgroup
 }
 
+// The health statuses of containers with health checks; containers without
+// health checks or with unknown health have the zero health status.
+const (
+	HealthStarting  = "starting"  // health check hasn't yet succeeded or failed.
+	HealthHealthy   = "healthy"   // health check succeeds.
+	HealthUnhealthy = "unhealthy" // health check fails.
+)
+
 // ProjectName returns the name of the composer project for this container, if
 // any; otherwise, it returns "" if a container isn't associated with a composer
 // project.
@@ -0,0 +1,179 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// PortfolioDiff describes the differences between two portfolio [Snapshot]s,
+// grouped in the same way as the snapshots, which usually is by composer
+// project.
+type PortfolioDiff struct {
+	From     uint64                  // generation of the older snapshot, or zero.
+	To       uint64                  // generation of the newer snapshot, or zero.
+	Projects map[string]*ProjectDiff // only projects (groups) with changes, by name.
+}
+
+// ProjectDiff describes the container differences within a single composer
+// project or group (including the zero project). All container lists are
+// sorted by container ID, and then by container name.
+type ProjectDiff struct {
+	Name     string            // composer project (group) name.
+	Added    []*Container      // containers that have become alive.
+	Removed  []*Container      // containers that have gone.
+	Paused   []*Container      // containers that have been paused, in their new state.
+	Unpaused []*Container      // containers that have been unpaused, in their new state.
+	Changed  []ContainerChange // containers otherwise changed, such as their labels.
+}
+
+// ContainerChange describes a container that has changed other than in its
+// paused state, such as in its PID, health, or labels. Container Rucksacks are
+// not taken into consideration.
+type ContainerChange struct {
+	Old *Container // container in its previous state.
+	New *Container // container in its new state.
+}
+
+// diffKey identifies a container across snapshots; as container IDs are not
+// necessarily unique, such as across containerd namespaces, the key
+// additionally includes the container name.
+type diffKey struct {
+	id   string
+	name string
+}
+
+// diffEntry is a container together with the name of the group it belongs to
+// in its snapshot.
+type diffEntry struct {
+	cntr  *Container
+	group string
+}
+
+// diffEntries returns the containers of the specified snapshot, keyed by their
+// IDs and names. A nil snapshot has no containers.
+func diffEntries(s *Snapshot) map[diffKey]diffEntry {
+	entries := map[diffKey]diffEntry{}
+	if s == nil {
+		return entries
+	}
+	for group, cntrs := range s.projects {
+		for _, cntr := range cntrs {
+			entries[diffKey{id: cntr.ID, name: cntr.Name}] = diffEntry{cntr: cntr, group: group}
+		}
+	}
+	return entries
+}
+
+// Diff returns the differences between two portfolio snapshots, where from is
+// the older snapshot and to the newer snapshot. Either snapshot can be nil,
+// which then is treated as an empty portfolio.
+//
+// Containers are matched by their IDs and names, so a renamed container is
+// reported as removed and added. Containers are grouped the same way as in
+// the snapshots, that is, using the [Grouping] of the portfolios the snapshots
+// were taken from. A container that has both changed its paused state and
+// otherwise changed is reported as (un)paused as well as changed.
+func Diff(from, to *Snapshot) *PortfolioDiff {
+	d := &PortfolioDiff{
+		Projects: map[string]*ProjectDiff{},
+	}
+	if from != nil {
+		d.From = from.generation
+	}
+	if to != nil {
+		d.To = to.generation
+	}
+	fromcntrs := diffEntries(from)
+	tocntrs := diffEntries(to)
+	for key, newe := range tocntrs {
+		olde, ok := fromcntrs[key]
+		if !ok || olde.group != newe.group {
+			// In the unlikely case of a container having moved between
+			// projects (groups) we treat this as the container having gone
+			// from its old project and newly appeared in its new project.
+			if ok {
+				pd := d.project(olde.group)
+				pd.Removed = append(pd.Removed, olde.cntr)
+			}
+			pd := d.project(newe.group)
+			pd.Added = append(pd.Added, newe.cntr)
+			continue
+		}
+		oldc, newc := olde.cntr, newe.cntr
+		if oldc.Paused != newc.Paused {
+			pd := d.project(newe.group)
+			if newc.Paused {
+				pd.Paused = append(pd.Paused, newc)
+			} else {
+				pd.Unpaused = append(pd.Unpaused, newc)
+			}
+		}
+		if oldc.PID != newc.PID || oldc.Health != newc.Health ||
+			!maps.Equal(oldc.Labels, newc.Labels) {
+			pd := d.project(newe.group)
+			pd.Changed = append(pd.Changed, ContainerChange{Old: oldc, New: newc})
+		}
+	}
+	for key, olde := range fromcntrs {
+		if _, ok := tocntrs[key]; !ok {
+			pd := d.project(olde.group)
+			pd.Removed = append(pd.Removed, olde.cntr)
+		}
+	}
+	for _, pd := range d.Projects {
+		pd.sort()
+	}
+	return d
+}
+
+// IsEmpty returns true if there are no differences at all.
+func (d *PortfolioDiff) IsEmpty() bool {
+	return len(d.Projects) == 0
+}
+
+// IsEmpty returns true if there are no differences in this project.
+func (pd *ProjectDiff) IsEmpty() bool {
+	return len(pd.Added) == 0 && len(pd.Removed) == 0 &&
+		len(pd.Paused) == 0 && len(pd.Unpaused) == 0 && len(pd.Changed) == 0
+}
+
+// project returns the project diff for the named project, creating it first
+// if necessary.
+func (d *PortfolioDiff) project(name string) *ProjectDiff {
+	pd, ok := d.Projects[name]
+	if !ok {
+		pd = &ProjectDiff{Name: name}
+		d.Projects[name] = pd
+	}
+	return pd
+}
+
+// sort the container lists of this project diff by container ID and name.
+func (pd *ProjectDiff) sort() {
+	byIDName := func(a, b *Container) int {
+		return cmp.Or(strings.Compare(a.ID, b.ID), strings.Compare(a.Name, b.Name))
+	}
+	slices.SortFunc(pd.Added, byIDName)
+	slices.SortFunc(pd.Removed, byIDName)
+	slices.SortFunc(pd.Paused, byIDName)
+	slices.SortFunc(pd.Unpaused, byIDName)
+	slices.SortFunc(pd.Changed, func(a, b ContainerChange) int {
+		return byIDName(a.New, b.New)
+	})
+}
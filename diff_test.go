@@ -0,0 +1,139 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("portfolio diffs", func() {
+
+	It("reports no differences for unchanged portfolios", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		d := Diff(pf.Snapshot(), pf.Snapshot())
+		Expect(d.IsEmpty()).To(BeTrue())
+		Expect(d.From).To(Equal(d.To))
+
+		Expect(Diff(nil, nil).IsEmpty()).To(BeTrue())
+	})
+
+	It("treats nil snapshots as empty portfolios", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		s := pf.Snapshot()
+
+		d := Diff(nil, s)
+		Expect(d.From).To(BeZero())
+		Expect(d.To).To(Equal(s.Generation()))
+		Expect(d.Projects).To(HaveKeyWithValue("grumpy",
+			HaveField("Added", ConsistOf(HaveField("ID", "1")))))
+
+		d = Diff(s, nil)
+		Expect(d.Projects).To(HaveKeyWithValue("grumpy",
+			HaveField("Removed", ConsistOf(HaveField("ID", "1")))))
+	})
+
+	It("reports differences grouped by project", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", Project: "grumpy"})
+		pf.Add(&Container{ID: "3", Name: "pompous_paperboard", Paused: true})
+		pf.Add(&Container{ID: "4", Name: "mad_mary", PID: 42, Labels: map[string]string{"foo": "bar"}})
+		from := pf.Snapshot()
+
+		pf.Remove("1", "grumpy")
+		pf.Add(&Container{ID: "5", Name: "porose_porpoise", Project: "rumpelpumpel"})
+		pf.Project("grumpy").SetPaused("2", true)
+		pf.Project("").SetPaused("3", false)
+		pf.Project("").SetHealth("3", HealthHealthy)
+		pf.Remove("4", "")
+		pf.Add(&Container{ID: "4", Name: "mad_mary", PID: 42, Labels: map[string]string{"foo": "baz"}})
+		to := pf.Snapshot()
+
+		d := Diff(from, to)
+		Expect(d.IsEmpty()).To(BeFalse())
+		Expect(d.From).To(Equal(from.Generation()))
+		Expect(d.To).To(Equal(to.Generation()))
+		Expect(d.Projects).To(HaveLen(3))
+
+		grumpy := d.Projects["grumpy"]
+		Expect(grumpy.IsEmpty()).To(BeFalse())
+		Expect(grumpy.Removed).To(ConsistOf(HaveField("ID", "1")))
+		Expect(grumpy.Paused).To(ConsistOf(And(HaveField("ID", "2"), HaveField("Paused", true))))
+		Expect(grumpy.Added).To(BeEmpty())
+		Expect(grumpy.Changed).To(BeEmpty())
+
+		Expect(d.Projects["rumpelpumpel"].Added).To(ConsistOf(HaveField("ID", "5")))
+
+		zero := d.Projects[""]
+		Expect(zero.Unpaused).To(ConsistOf(HaveField("ID", "3")))
+		Expect(zero.Changed).To(ConsistOf(
+			And(
+				HaveField("Old.Labels", HaveKeyWithValue("foo", "bar")),
+				HaveField("New.Labels", HaveKeyWithValue("foo", "baz"))),
+			And(
+				HaveField("Old.Health", BeEmpty()),
+				HaveField("New.Health", HealthHealthy))))
+	})
+
+	It("sorts containers by ID", func() {
+		pf := NewPortfolio()
+		from := pf.Snapshot()
+		pf.Add(&Container{ID: "3", Name: "pompous_paperboard"})
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle"})
+		pf.Add(&Container{ID: "2", Name: "murky_moby"})
+
+		Expect(Diff(from, pf.Snapshot()).Projects[""].Added).To(HaveExactElements(
+			HaveField("ID", "1"), HaveField("ID", "2"), HaveField("ID", "3")))
+	})
+
+	It("tells apart containers with the same ID", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "default/furious_furuncle"})
+		from := pf.Snapshot()
+		pf.Add(&Container{ID: "1", Name: "k8s.io/furious_furuncle"})
+		pf.Project("").SetPaused("default/furious_furuncle", true)
+
+		zero := Diff(from, pf.Snapshot()).Projects[""]
+		Expect(zero.Added).To(ConsistOf(HaveField("Name", "k8s.io/furious_furuncle")))
+		Expect(zero.Paused).To(ConsistOf(HaveField("Name", "default/furious_furuncle")))
+		Expect(zero.Removed).To(BeEmpty())
+
+		pf.Remove("k8s.io/furious_furuncle", "")
+		zero = Diff(from, pf.Snapshot()).Projects[""]
+		Expect(zero.Added).To(BeEmpty())
+		Expect(zero.Removed).To(BeEmpty())
+	})
+
+	It("groups the same way as the snapshots", func() {
+		pf := NewPortfolio(WithGrouping(GroupByLabel("tier")))
+		from := pf.Snapshot()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy",
+			Labels: map[string]string{"tier": "web"}})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", Project: "grumpy"})
+		to := pf.Snapshot()
+		Expect(to.Project("web")).To(HaveLen(1))
+
+		d := Diff(from, to)
+		Expect(d.Projects).To(HaveLen(2))
+		Expect(d.Projects).To(HaveKeyWithValue("web",
+			HaveField("Added", ConsistOf(HaveField("ID", "1")))))
+		Expect(d.Projects).To(HaveKeyWithValue("",
+			HaveField("Added", ConsistOf(HaveField("ID", "2")))))
+	})
+
+})
@@ -104,6 +104,75 @@ The container information model starts with the [Portfolio]: a Portfolio
 consists of one or more projects in form of [ComposerProject], including the
 "unnamed" ComposerProject (that contains all non-project containers).
 
+By default, a Portfolio groups containers by their composer projects. Using
+[WithGrouping], portfolios can group containers differently instead, such as by
+a label using [GroupByLabel], or by Kubernetes pod or Docker Swarm stack using
+the groupings from the CRI and Docker engine client packages. Each group then
+is represented by a ComposerProject named after the group.
+
+# Snapshot
+
+A [Snapshot] is an immutable, point-in-time view of a [Portfolio], taken using
+[Portfolio.Snapshot]. Snapshots come with the generation number of the
+Portfolio at the time of taking the snapshot, so applications can easily tell
+whether anything has changed since they last looked. Generation numbers only
+increase, even across the Portfolio swaps done by watchers when
+resynchronizing with their container engines.
+
+[Diff] compares two snapshots and reports the containers that have been added,
+removed, paused, unpaused, or otherwise changed, grouped by composer project.
+
+# Observers
+
+Derived indices and caches can stay in sync with a Portfolio by registering an
+[Observer] with [Portfolio.Observe]. Observers get notified about containers
+being added and removed, containers getting paused and unpaused, changes in
+the health of containers, as well as composer projects (groups) appearing and
+disappearing, regardless of whether a watcher or the application itself
+changes the Portfolio. [ObserverFuncs] allows supplying only the notifications
+of interest.
+
+# Label Selectors
+
+A [Selector] selects containers by their labels, using a syntax in the style
+of Kubernetes label selectors, such as "app=web,tier!=db,env in
+(prod,stage),!debug"; please see [ParseSelector] for details. Use
+[Portfolio.Select] to iterate over only the matching containers of a
+Portfolio, or [Container.Matches] to check individual containers.
+
+# Process Owners
+
+Portfolios only know about the initial ("ealdorman") processes of containers.
+An [OwnerFinder] maps arbitrary process PIDs to their owning containers by
+walking up the process ancestry until hitting a known initial container
+process, falling back to cgroup memberships for processes that have been
+"exec'ed" into containers.
+
+# Namespaces
+
+Containers optionally carry the inode numbers of the Linux [Namespaces] of
+their initial processes, as read by [ReadNamespaces]. Watchers discover them
+when created with the watcher.WithNamespaces option.
+[Portfolio.ContainersInNamespace] then answers which containers are attached
+to a particular namespace, such as a specific network namespace.
+
+# Cgroups
+
+Containers optionally carry the [Cgroup] of their initial processes, as read
+by [ReadCgroup] from "/proc/PID/cgroup" for both cgroup v2 and v1 systems.
+Watchers discover them when created with the watcher.WithCgroups option.
+
+# JSON
+
+Portfolios, snapshots, composer projects, and containers can be marshalled to
+and unmarshalled from JSON, for instance, in order to ship them between
+processes or to use them as test fixtures; please see [Portfolio.MarshalJSON]
+for the JSON schema. Container Rucksacks are marshalled using their own JSON
+representation; use [UnmarshalPortfolio] with a [RucksackUnpacker] to restore
+Rucksacks when unmarshalling. [SaveCheckpoint] and [LoadCheckpoint] use the same
+JSON representation to persist portfolios to disk, such as for warm-starting
+watchers.
+
 # ComposerProject
 
 Composer projects are either explicitly named, or the "zero" project that has no
@@ -120,6 +189,13 @@ recent updated state (where necessary) by retrieving the [ComposerProject] from
 the Watcher, and then querying for your Container using
 [ComposerProject.Container].
 
+Applications can augment containers with their own information by packing it
+into the container's Rucksack, using a RucksackPacker of an engine client. The
+engine clients' WithTypedRucksackPacker options check the type of inspection
+information a packer expects at compile time, while [RucksackOf] retrieves a
+Rucksack of the expected type, reporting missing Rucksacks and type mismatches
+as errors.
+
 [Docker compose]: https://github.com/docker/compose
 
 [nerdctl issue #241]: https://github.com/containerd/nerdctl/issues/241
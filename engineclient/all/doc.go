@@ -0,0 +1,13 @@
+/*
+Package all registers the engine URL schemes of all built-in engine clients, for
+use with engineclient.Open and watcher.Open. Import it for its side effects
+only:
+
+	import _ "github.com/thediveo/whalewatcher/v2/engineclient/all"
+
+The registered URL schemes are "docker", "containerd", "cri", "podman",
+"incus", "lxd", "machined", and "ociruntime". All schemes accept an optional
+"+unix" transport, such as "docker+unix"; the "docker" and "podman" schemes
+additionally accept the "+tcp" transport.
+*/
+package all
@@ -20,6 +20,7 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	apievents "github.com/containerd/containerd/api/events"
 	"github.com/containerd/containerd/api/services/tasks/v1"
@@ -37,6 +38,9 @@ import (
 // Type specifies this container engine's type identifier.
 const Type = "containerd.io"
 
+// DefaultSocket is the default path of containerd's API socket.
+const DefaultSocket = "/run/containerd/containerd.sock"
+
 // IgnoredNamespaces defines the default configuration of containerd namespaces
 // ignored by this engine client. Use the IgnoreNamespace option to set a
 // different set when creating a new engine client.
@@ -108,13 +112,20 @@ func WithIgnoredNamespaces(ignores []string) NewOption {
 // WithRucksackPacker sets the Rucksack packer that adds application-specific
 // container information based on the inspected container data. The specified
 // Rucksack packer gets passed the inspection data in form of a
-// InspectionDetails.
+// InspectionDetails. Please consider using [WithTypedRucksackPacker] instead.
 func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
 	return func(cw *ContainerdWatcher) {
 		cw.packer = packer
 	}
 }
 
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[InspectionDetails]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
 // InspectionDetails combines the container inspection details with its task
 // process details. InspectionDetails gets passed to Rucksack packers where
 // registered in order to pick additional inspection information beyond the
@@ -291,7 +302,10 @@ func (cw *ContainerdWatcher) newContainer(namespace string, labels map[string]st
 
 // LifecycleEvents streams container engine events, limited just to those events
 // in the lifecycle of containers getting born (=alive, as opposed to, say,
-// "conceived") and die.
+// "conceived") and die, as well as informational lifecycle events, such as
+// containers getting created and deleted, and OOM kills. Exit events carry
+// exit information; their runtime is known only for containers that were
+// started while streaming events.
 func (cw *ContainerdWatcher) LifecycleEvents(ctx context.Context) (
 	<-chan engineclient.ContainerEvent, <-chan error,
 ) {
@@ -306,7 +320,16 @@ func (cw *ContainerdWatcher) LifecycleEvents(ctx context.Context) (
 			`topic=="/tasks/start"`,
 			`topic=="/tasks/exit"`,
 			`topic=="/tasks/paused"`,
-			`topic=="/tasks/resumed"`)
+			`topic=="/tasks/resumed"`,
+			`topic=="/tasks/oom"`,
+			`topic=="/containers/create"`,
+			`topic=="/containers/delete"`)
+		// As task exit events carry neither the start time nor whether a task
+		// has been OOM-killed, remember these details from the earlier task
+		// events until the tasks exit, or are started anew, or their containers
+		// get deleted.
+		startedAt := map[string]time.Time{}
+		oomkilled := map[string]struct{}{}
 		for {
 			select {
 			case err := <-errs:
@@ -334,10 +357,13 @@ func (cw *ContainerdWatcher) LifecycleEvents(ctx context.Context) (
 					if err := typeurl.UnmarshalTo(env.Event, &taskstart); err != nil {
 						continue
 					}
+					id := displayID(env.Namespace, taskstart.ContainerID)
+					startedAt[id] = env.Timestamp
+					delete(oomkilled, id)
 					cntreventstream <- engineclient.ContainerEvent{
 						Timestamp: env.Timestamp,
 						Type:      engineclient.ContainerStarted,
-						ID:        displayID(env.Namespace, taskstart.ContainerID),
+						ID:        id,
 						Project:   engineclient.ProjectUnknown,
 					}
 				case "/tasks/exit":
@@ -345,11 +371,30 @@ func (cw *ContainerdWatcher) LifecycleEvents(ctx context.Context) (
 					if err := typeurl.UnmarshalTo(env.Event, &taskexit); err != nil {
 						continue
 					}
+					// Skip exited exec processes, as otherwise we would
+					// report their exit codes as the container's.
+					if taskexit.ID != "" && taskexit.ID != taskexit.ContainerID {
+						continue
+					}
+					id := displayID(env.Namespace, taskexit.ContainerID)
+					exit := &engineclient.ExitInfo{
+						ExitCode: int(taskexit.ExitStatus),
+						ExitedAt: taskexit.ExitedAt.AsTime(),
+					}
+					if started, ok := startedAt[id]; ok {
+						exit.Runtime = exit.ExitedAt.Sub(started)
+						delete(startedAt, id)
+					}
+					if _, ok := oomkilled[id]; ok {
+						exit.OOMKilled = true
+						delete(oomkilled, id)
+					}
 					cntreventstream <- engineclient.ContainerEvent{
 						Timestamp: env.Timestamp,
 						Type:      engineclient.ContainerExited,
-						ID:        displayID(env.Namespace, taskexit.ContainerID),
+						ID:        id,
 						Project:   engineclient.ProjectUnknown,
+						Exit:      exit,
 					}
 				case "/tasks/paused":
 					var taskpaused apievents.TaskPaused
@@ -373,6 +418,44 @@ func (cw *ContainerdWatcher) LifecycleEvents(ctx context.Context) (
 						ID:        displayID(env.Namespace, taskresumed.ContainerID),
 						Project:   engineclient.ProjectUnknown,
 					}
+				case "/tasks/oom":
+					var taskoom apievents.TaskOOM
+					if err := typeurl.UnmarshalTo(env.Event, &taskoom); err != nil {
+						continue
+					}
+					id := displayID(env.Namespace, taskoom.ContainerID)
+					oomkilled[id] = struct{}{}
+					cntreventstream <- engineclient.ContainerEvent{
+						Timestamp: env.Timestamp,
+						Type:      engineclient.ContainerOOMKilled,
+						ID:        id,
+						Project:   engineclient.ProjectUnknown,
+					}
+				case "/containers/create":
+					var cntrcreate apievents.ContainerCreate
+					if err := typeurl.UnmarshalTo(env.Event, &cntrcreate); err != nil {
+						continue
+					}
+					cntreventstream <- engineclient.ContainerEvent{
+						Timestamp: env.Timestamp,
+						Type:      engineclient.ContainerCreated,
+						ID:        displayID(env.Namespace, cntrcreate.ID),
+						Project:   engineclient.ProjectUnknown,
+					}
+				case "/containers/delete":
+					var cntrdelete apievents.ContainerDelete
+					if err := typeurl.UnmarshalTo(env.Event, &cntrdelete); err != nil {
+						continue
+					}
+					id := displayID(env.Namespace, cntrdelete.ID)
+					delete(startedAt, id)
+					delete(oomkilled, id)
+					cntreventstream <- engineclient.ContainerEvent{
+						Timestamp: env.Timestamp,
+						Type:      engineclient.ContainerRemoved,
+						ID:        id,
+						Project:   engineclient.ProjectUnknown,
+					}
 				}
 			}
 		}
@@ -0,0 +1,61 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package containerd
+
+import (
+	"net/url"
+
+	"github.com/containerd/containerd/v2/client"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for containerd engines, such as
+// "containerd:///run/containerd/containerd.sock?ignore=moby,k8s.io". With an
+// empty path, [DefaultSocket] applies. Query parameters are "pid" and "ignore"
+// (see [WithIgnoredNamespaces]).
+const Scheme = "containerd"
+
+func init() {
+	engineclient.Register(Scheme, open)
+}
+
+// open returns a new ContainerdWatcher configured by the specified engine URL.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid", "ignore")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	sockpath, err := engineclient.SocketPath(u)
+	if err != nil {
+		return nil, err
+	}
+	if sockpath == "" {
+		sockpath = DefaultSocket
+	}
+	cdclient, err := client.New(sockpath)
+	if err != nil {
+		return nil, err
+	}
+	opts := []NewOption{WithPID(pid)}
+	if query.Has("ignore") {
+		opts = append(opts, WithIgnoredNamespaces(engineclient.QueryList(query, "ignore")))
+	}
+	return NewContainerdWatcher(cdclient, opts...), nil
+}
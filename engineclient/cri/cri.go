@@ -102,13 +102,30 @@ func WithPID(pid int) NewOption {
 // WithRucksackPacker sets the Rucksack packer that adds application-specific
 // container information based on the inspected container data. The specified
 // Rucksack packer gets passed the inspection data in form of
-// InspectionDetails.
+// InspectionDetails. Please consider using [WithTypedRucksackPacker] instead.
 func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
 	return func(cw *CRIWatcher) {
 		cw.packer = packer
 	}
 }
 
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[InspectionDetails]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
+// InspectionDetails combines the CRI container details with the details of
+// the pod sandbox the container belongs to. InspectionDetails gets passed to
+// Rucksack packers where registered in order to pick additional inspection
+// information beyond the generic staple data maintained by the whalewatcher
+// module. For pod sandbox containers, Container is nil.
+type InspectionDetails struct {
+	Container  *runtime.Container
+	PodSandbox *runtime.PodSandbox
+}
+
 // ID returns the (more or less) unique engine identifier; the exact format is
 // engine-specific. Unfortunately, the CRI API doesn't has any concept or notion
 // of individual “engine identification”. We thus synthesize one from the host
@@ -287,13 +304,23 @@ func (cw *CRIWatcher) newContainer(
 		labels[PodSandboxLabel] = "" // exact value doesn't matter
 	}
 
-	return &whalewatcher.Container{
+	c := &whalewatcher.Container{
 		ID:     cntr.Id,
 		Name:   cntr.Metadata.Name,
 		Labels: labels,
 		PID:    innerInfo.PID,
 		Paused: false, // there is no pause notion in Kubernetes
 	}
+	if startedAt := status.GetStatus().GetStartedAt(); startedAt != 0 {
+		c.StartedAt = time.Unix(0, startedAt)
+	}
+	if cw.packer != nil {
+		cw.packer.Pack(c, InspectionDetails{
+			Container:  cntr,
+			PodSandbox: pods.Items[0],
+		})
+	}
+	return c
 }
 
 // newSandboxContainer returns the container details of a pod sandbox of
@@ -350,18 +377,25 @@ func (cw *CRIWatcher) newSandboxContainer(
 
 	labels[PodSandboxLabel] = "" // exact value doesn't matter
 
-	return &whalewatcher.Container{
+	c := &whalewatcher.Container{
 		ID:     sandbox.Id,
 		Name:   sandbox.Id,
 		Labels: labels,
 		PID:    innerInfo.PID,
 		Paused: false, // there is no pause notion in Kubernetes
 	}
+	if cw.packer != nil {
+		cw.packer.Pack(c, InspectionDetails{
+			PodSandbox: sandbox,
+		})
+	}
+	return c
 }
 
 // LifecycleEvents streams container engine events, limited just to those events
 // in the lifecycle of containers getting born (=alive, as opposed to, say,
-// “conceived”) and die.
+// “conceived”) and die, as well as informational lifecycle events of
+// containers getting created and deleted.
 func (cw *CRIWatcher) LifecycleEvents(ctx context.Context) (
 	<-chan engineclient.ContainerEvent, <-chan error,
 ) {
@@ -407,6 +441,19 @@ func (cw *CRIWatcher) LifecycleEvents(ctx context.Context) (
 					Timestamp: time.Unix(0, ev.CreatedAt),
 					Type:      engineclient.ContainerExited,
 					ID:        ev.ContainerId, // use ID to be unambiguous
+					Exit:      exitInfo(ev),
+				}
+			case runtime.ContainerEventType_CONTAINER_CREATED_EVENT:
+				cntreventstream <- engineclient.ContainerEvent{
+					Timestamp: time.Unix(0, ev.CreatedAt),
+					Type:      engineclient.ContainerCreated,
+					ID:        ev.ContainerId, // use ID to be unambiguous
+				}
+			case runtime.ContainerEventType_CONTAINER_DELETED_EVENT:
+				cntreventstream <- engineclient.ContainerEvent{
+					Timestamp: time.Unix(0, ev.CreatedAt),
+					Type:      engineclient.ContainerRemoved,
+					ID:        ev.ContainerId, // use ID to be unambiguous
 				}
 			}
 		}
@@ -414,3 +461,26 @@ func (cw *CRIWatcher) LifecycleEvents(ctx context.Context) (
 
 	return cntreventstream, cntrerrstream
 }
+
+// exitInfo returns the exit information of the stopped container the specified
+// container event is about, or nil if the event doesn't carry the stopped
+// container's status. Pod sandboxes never carry any exit information.
+func exitInfo(ev *runtime.ContainerEventResponse) *engineclient.ExitInfo {
+	for _, status := range ev.ContainersStatuses {
+		if status.Id != ev.ContainerId {
+			continue
+		}
+		exit := &engineclient.ExitInfo{
+			ExitCode:  int(status.ExitCode),
+			OOMKilled: status.Reason == "OOMKilled",
+		}
+		if status.FinishedAt != 0 {
+			exit.ExitedAt = time.Unix(0, status.FinishedAt)
+			if status.StartedAt != 0 {
+				exit.Runtime = exit.ExitedAt.Sub(time.Unix(0, status.StartedAt))
+			}
+		}
+		return exit
+	}
+	return nil
+}
@@ -62,6 +62,14 @@ simply prefix all annotation keys with “annotation.k8s/”. Yes, that's “k8s
 not “k8s.io”, as Kubernetes deserves its own TLD anyway and we don't want to
 mess with the “k8s.io” domain.
 
+# Pods
+
+As the whalewatcher model flattens pods into container labels, [Pods] and
+[NewPodView] reconstruct the pods from the containers of a portfolio (snapshot)
+in a [PodView]. A PodView groups the sandbox and workload containers of each
+[Pod] by pod UID and allows looking up pods by their namespaces and names.
+Alternatively, [GroupByPod] groups the containers of a portfolio by pod.
+
 # CRI API Model
 
 The [CRI API] obviously has been designed to primarily serve the needs of
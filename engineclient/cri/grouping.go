@@ -0,0 +1,30 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+)
+
+// GroupByPod is a [whalewatcher.Grouping] that groups containers by their pods,
+// using group names in "namespace/name" format. Containers not belonging to
+// any pod end up in their composer projects instead.
+func GroupByPod(cntr *whalewatcher.Container) string {
+	name, ok := cntr.Labels[PodNameLabel]
+	if !ok {
+		return cntr.Project
+	}
+	return cntr.Labels[PodNamespaceLabel] + "/" + name
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("grouping", func() {
+
+	It("groups by pod", func() {
+		Expect(GroupByPod(&whalewatcher.Container{
+			Labels: map[string]string{
+				PodNamespaceLabel: "default",
+				PodNameLabel:      "podzilla",
+			},
+		})).To(Equal("default/podzilla"))
+		Expect(GroupByPod(&whalewatcher.Container{Project: "grumpy"})).To(Equal("grumpy"))
+	})
+
+})
@@ -0,0 +1,124 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/thediveo/whalewatcher/v2"
+)
+
+// Pod represents a Kubernetes pod with its sandbox and workload containers, as
+// reconstructed from the pod labels of containers.
+type Pod struct {
+	UID        string                    // pod UID.
+	Name       string                    // pod name.
+	Namespace  string                    // pod namespace.
+	Sandbox    *whalewatcher.Container   // pod sandbox container, if known.
+	Containers []*whalewatcher.Container // workload containers, sorted by name.
+}
+
+// PodView is an immutable view on the pods of a [whalewatcher.Portfolio] at a
+// particular point in time.
+type PodView struct {
+	generation uint64
+	pods       []*Pod          // sorted by namespace and name.
+	uids       map[string]*Pod // pods by UID.
+	names      map[string]*Pod // pods by "namespace/name".
+}
+
+// Pods returns a view on the pods of the specified portfolio, based on a
+// consistent snapshot of the portfolio.
+func Pods(pf *whalewatcher.Portfolio) *PodView {
+	return NewPodView(pf.Snapshot())
+}
+
+// NewPodView returns a view on the pods of the specified portfolio snapshot.
+// Pods are identified by the [PodUidLabel] of containers, so containers
+// without this label are not part of any pod. The sandbox container of a pod
+// is identified by the presence of the [PodSandboxLabel].
+func NewPodView(s *whalewatcher.Snapshot) *PodView {
+	v := &PodView{
+		generation: s.Generation(),
+		uids:       map[string]*Pod{},
+		names:      map[string]*Pod{},
+	}
+	for cntr := range s.AllContainers() {
+		uid, ok := cntr.Labels[PodUidLabel]
+		if !ok {
+			continue
+		}
+		pod, ok := v.uids[uid]
+		if !ok {
+			pod = &Pod{
+				UID:       uid,
+				Name:      cntr.Labels[PodNameLabel],
+				Namespace: cntr.Labels[PodNamespaceLabel],
+			}
+			v.uids[uid] = pod
+			v.pods = append(v.pods, pod)
+		}
+		if _, ok := cntr.Labels[PodSandboxLabel]; ok {
+			pod.Sandbox = cntr
+			continue
+		}
+		pod.Containers = append(pod.Containers, cntr)
+	}
+	for _, pod := range v.pods {
+		slices.SortFunc(pod.Containers, func(a, b *whalewatcher.Container) int {
+			return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID, b.ID))
+		})
+		v.names[pod.Namespace+"/"+pod.Name] = pod
+	}
+	slices.SortFunc(v.pods, func(a, b *Pod) int {
+		return cmp.Or(strings.Compare(a.Namespace, b.Namespace), strings.Compare(a.Name, b.Name))
+	})
+	return v
+}
+
+// Generation returns the generation number of the portfolio this view is
+// based on.
+func (v *PodView) Generation() uint64 {
+	return v.generation
+}
+
+// All returns all pods, sorted by their namespaces and names.
+func (v *PodView) All() []*Pod {
+	return slices.Clone(v.pods)
+}
+
+// Pod returns the pod with the specified namespace and name, or nil if there
+// is no such pod.
+func (v *PodView) Pod(namespace, name string) *Pod {
+	return v.names[namespace+"/"+name]
+}
+
+// PodByUID returns the pod with the specified UID, or nil if there is no such
+// pod.
+func (v *PodView) PodByUID(uid string) *Pod {
+	return v.uids[uid]
+}
+
+// PodOf returns the pod the specified container belongs to, or nil if the
+// container doesn't belong to any pod in this view.
+func (v *PodView) PodOf(cntr *whalewatcher.Container) *Pod {
+	uid, ok := cntr.Labels[PodUidLabel]
+	if !ok {
+		return nil
+	}
+	return v.uids[uid]
+}
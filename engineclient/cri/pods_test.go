@@ -0,0 +1,74 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// podContainer returns a container belonging to the specified pod.
+func podContainer(id, name, uid, namespace, podname string, sandbox bool) *whalewatcher.Container {
+	labels := map[string]string{
+		PodUidLabel:           uid,
+		PodNameLabel:          podname,
+		PodNamespaceLabel:     namespace,
+		PodContainerNameLabel: name,
+	}
+	if sandbox {
+		labels[PodSandboxLabel] = ""
+	}
+	return &whalewatcher.Container{ID: id, Name: name, Labels: labels}
+}
+
+var _ = Describe("pods", func() {
+
+	It("returns a view on pods", func() {
+		pf := whalewatcher.NewPortfolio()
+		pf.Add(podContainer("1", "1", "uid-1", "default", "podzilla", true))
+		pf.Add(podContainer("2", "sidecar", "uid-1", "default", "podzilla", false))
+		pf.Add(podContainer("3", "main", "uid-1", "default", "podzilla", false))
+		pf.Add(podContainer("4", "main", "uid-2", "kube-system", "podmonster", false))
+		pf.Add(podContainer("5", "main", "uid-3", "default", "kingpod", false))
+		pf.Add(&whalewatcher.Container{ID: "6", Name: "loner"})
+
+		v := Pods(pf)
+		Expect(v.Generation()).To(Equal(pf.Generation()))
+		Expect(v.All()).To(HaveExactElements(
+			HaveField("Name", "kingpod"),
+			HaveField("Name", "podzilla"),
+			HaveField("Name", "podmonster")))
+
+		podzilla := v.Pod("default", "podzilla")
+		Expect(podzilla).NotTo(BeNil())
+		Expect(podzilla.UID).To(Equal("uid-1"))
+		Expect(podzilla.Sandbox).To(HaveField("ID", "1"))
+		Expect(podzilla.Containers).To(HaveExactElements(
+			HaveField("Name", "main"),
+			HaveField("Name", "sidecar")))
+
+		podmonster := v.PodByUID("uid-2")
+		Expect(podmonster).To(HaveField("Namespace", "kube-system"))
+		Expect(podmonster.Sandbox).To(BeNil())
+
+		Expect(v.Pod("default", "podmonster")).To(BeNil())
+		Expect(v.PodByUID("uid-42")).To(BeNil())
+		Expect(v.PodOf(pf.Container("4"))).To(BeIdenticalTo(podmonster))
+		Expect(v.PodOf(pf.Container("loner"))).To(BeNil())
+	})
+
+})
@@ -0,0 +1,66 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cri
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for CRI engines, such as
+// "cri:///run/crio/crio.sock". As there is no default CRI API socket, the path
+// must not be empty. Query parameters are "pid" and "timeout" (see
+// [WithTimeout]), with the timeout in [time.ParseDuration] format.
+const Scheme = "cri"
+
+func init() {
+	engineclient.Register(Scheme, open)
+}
+
+// open returns a new CRIWatcher configured by the specified engine URL.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid", "timeout")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	sockpath, err := engineclient.SocketPath(u)
+	if err != nil {
+		return nil, err
+	}
+	if sockpath == "" {
+		return nil, errors.New("CRI engine URL lacks API socket path")
+	}
+	var clientopts []ClientOpt
+	if query.Has("timeout") {
+		timeout, err := time.ParseDuration(query.Get("timeout"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid engine URL timeout parameter %q", query.Get("timeout"))
+		}
+		clientopts = append(clientopts, WithTimeout(timeout))
+	}
+	criclient, err := New(sockpath, clientopts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewCRIWatcher(criclient, WithPID(pid)), nil
+}
@@ -17,6 +17,8 @@ package engineclient
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"reflect"
 	"time"
 
 	"github.com/thediveo/whalewatcher/v2"
@@ -78,6 +80,45 @@ type RucksackPacker interface {
 	Pack(container *whalewatcher.Container, inspection any)
 }
 
+// TypedRucksackPacker is the type-safe variant of a [RucksackPacker], getting
+// passed the engine-specific inspection information as type I. Use
+// [Untyped] to pass a TypedRucksackPacker where a RucksackPacker is expected,
+// or the type-safe options of the engine clients, such as
+// moby.WithTypedRucksackPacker.
+type TypedRucksackPacker[I any] interface {
+	Pack(container *whalewatcher.Container, inspection I)
+}
+
+// RucksackPackerFunc is an adapter allowing ordinary functions to be used as
+// TypedRucksackPackers; with I being "any", also as RucksackPackers.
+type RucksackPackerFunc[I any] func(container *whalewatcher.Container, inspection I)
+
+// Pack calls fn(container, inspection).
+func (fn RucksackPackerFunc[I]) Pack(container *whalewatcher.Container, inspection I) {
+	fn(container, inspection)
+}
+
+// Untyped returns a [RucksackPacker] that passes inspection information to the
+// specified TypedRucksackPacker. If an engine client passes inspection
+// information of a type other than I, the returned RucksackPacker doesn't pack
+// the container's Rucksack, leaving it untouched, and instead logs the type
+// mismatch as an error using the default [slog.Logger]. As packing happens
+// while watching, a misconfigured packer thus cannot crash the watching
+// process.
+func Untyped[I any](packer TypedRucksackPacker[I]) RucksackPacker {
+	return RucksackPackerFunc[any](func(container *whalewatcher.Container, inspection any) {
+		typed, ok := inspection.(I)
+		if !ok {
+			slog.Error("Rucksack packer got inspection information of unexpected type",
+				slog.String("container", container.Name),
+				slog.String("expected", reflect.TypeFor[I]().String()),
+				slog.String("got", fmt.Sprintf("%T", inspection)))
+			return
+		}
+		packer.Pack(container, typed)
+	})
+}
+
 // Trialer optionally allows an engine client to update cached engine
 // information on the first attempt or on any retry afterwards. Any error
 // returned will abort the current attempt, back off, and then a retry.
@@ -89,28 +130,96 @@ type Trialer interface {
 // of "alive" containers, including their demise. Please do not confuse this
 // lifecycle for alive containers with the usually much more comprehensive
 // container lifecycles that include creating a container long before it might
-// become alive: such stages are of no interest to us here; for instance,
-// there's no container creation event, "only" the container start event.
+// become alive: only the started, exited, paused, unpaused, and health changed
+// events affect the portfolio of alive containers.
+//
+// The remaining event types are informational only: they get passed on to
+// event consumers, but never change the portfolio. Not all container engines
+// support all informational event types:
+//   - Docker/Moby and Podman emit all informational event types.
+//   - containerd emits only [ContainerCreated], [ContainerRemoved], and
+//     [ContainerOOMKilled] events, but never [ContainerKilled],
+//     [ContainerRestarting], and [ContainerStopped] events, as containerd has
+//     neither a notion of gracefully stopping nor of restarting containers.
+//   - CRI emits only [ContainerCreated] and [ContainerRemoved] events.
+//   - Incus, systemd-machined, and OCI runtimes never emit informational
+//     events.
 type ContainerEventType byte
 
 const (
-	ContainerStarted  ContainerEventType = iota // container has been started
-	ContainerExited                             // container has terminated (exited)
-	ContainerPaused                             // container has been paused
-	ContainerUnpaused                           // container has been unpaused
+	ContainerStarted       ContainerEventType = iota // container has been started
+	ContainerExited                                  // container has terminated (exited)
+	ContainerPaused                                  // container has been paused
+	ContainerUnpaused                                // container has been unpaused
+	ContainerHealthChanged                           // container health status has changed
+
+	ContainerCreated    // container has been created, but not yet started
+	ContainerRemoved    // container has been removed
+	ContainerKilled     // container has been sent a signal
+	ContainerOOMKilled  // container process has been killed by the OOM killer
+	ContainerRestarting // container has been restarted
+	ContainerStopped    // container has been gracefully stopped using the engine's API, following its exit
 )
 
+// String returns the name of the container event type.
+func (t ContainerEventType) String() string {
+	switch t {
+	case ContainerStarted:
+		return "started"
+	case ContainerExited:
+		return "exited"
+	case ContainerPaused:
+		return "paused"
+	case ContainerUnpaused:
+		return "unpaused"
+	case ContainerHealthChanged:
+		return "health-changed"
+	case ContainerCreated:
+		return "created"
+	case ContainerRemoved:
+		return "removed"
+	case ContainerKilled:
+		return "killed"
+	case ContainerOOMKilled:
+		return "oom-killed"
+	case ContainerRestarting:
+		return "restarting"
+	case ContainerStopped:
+		return "stopped"
+	}
+	return fmt.Sprintf("ContainerEventType(%d)", t)
+}
+
+// IsInformational returns true if the container event type is only
+// informational and doesn't affect the portfolio of alive containers.
+func (t ContainerEventType) IsInformational() bool {
+	return t > ContainerHealthChanged
+}
+
 // ProjectUnknown signals that the project name for a container event is
 // unknown, as opposed to the zero project name.
 const ProjectUnknown = "\000"
 
 // ContainerEvent is either a container lifecycle event of a container becoming
-// alive, having died (more precise: its process exited), paused or unpaused.
+// alive, having died (more precise: its process exited), paused or unpaused,
+// or having changed its health status, or an informational lifecycle event,
+// such as a container having been created or removed.
 type ContainerEvent struct {
 	Timestamp time.Time          // for usecases such as audit logging, et cetera...
 	Type      ContainerEventType // type of lifecycle event.
 	ID        string             // ID (or name) of container.
 	Project   string             // optional composer project name, or zero.
+	Exit      *ExitInfo          // optional exit information for ContainerExited events.
+	Health    string             // new health status for ContainerHealthChanged events.
+}
+
+// ExitInfo informs about how and when a container exited. Not all container
+// engines report all exit information; unknown information is left zero.
+type ExitInfo struct {
+	ExitCode  int           // exit code of the container's initial process.
+	ExitedAt  time.Time     // when the container exited.
+	Runtime   time.Duration // how long the container ran, if known.
+	OOMKilled bool          // container has been killed by the OOM killer.
 }
 
 // ErrProcesslessContainer is a custom error indicating that inspecting
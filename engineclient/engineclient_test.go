@@ -0,0 +1,89 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engineclient
+
+import (
+	"bytes"
+	"log/slog"
+
+	"github.com/thediveo/whalewatcher/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type inspection struct {
+	Motto string
+}
+
+var _ = Describe("typed Rucksack packers", func() {
+
+	It("passes inspection information of the expected type", func() {
+		p := Untyped(RucksackPackerFunc[inspection](
+			func(container *whalewatcher.Container, inspection inspection) {
+				container.Rucksack = inspection.Motto
+			}))
+		c := &whalewatcher.Container{}
+		p.Pack(c, inspection{Motto: "I'm not dead yet"})
+		Expect(c.Rucksack).To(Equal("I'm not dead yet"))
+	})
+
+	It("skips packing and logs inspection information of the wrong type", func() {
+		var logs bytes.Buffer
+		defer slog.SetDefault(slog.Default())
+		slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+		packed := false
+		p := Untyped(RucksackPackerFunc[inspection](
+			func(*whalewatcher.Container, inspection) { packed = true }))
+		c := &whalewatcher.Container{Name: "furious_furuncle"}
+		Expect(func() { p.Pack(c, &inspection{}) }).NotTo(Panic())
+		Expect(packed).To(BeFalse())
+		Expect(c.Rucksack).To(BeNil())
+		Expect(logs.String()).To(And(
+			ContainSubstring("level=ERROR"),
+			ContainSubstring("container=furious_furuncle"),
+			ContainSubstring("expected=engineclient.inspection"),
+			ContainSubstring("got=*engineclient.inspection")))
+	})
+
+})
+
+var _ = Describe("container event types", func() {
+
+	It("stringifies", func() {
+		Expect(ContainerStarted.String()).To(Equal("started"))
+		Expect(ContainerOOMKilled.String()).To(Equal("oom-killed"))
+		Expect(ContainerStopped.String()).To(Equal("stopped"))
+		Expect(ContainerHealthChanged.String()).To(Equal("health-changed"))
+		Expect(ContainerEventType(42).String()).To(Equal("ContainerEventType(42)"))
+	})
+
+	It("tells informational events apart", func() {
+		for _, evtype := range []ContainerEventType{
+			ContainerStarted, ContainerExited, ContainerPaused, ContainerUnpaused,
+			ContainerHealthChanged,
+		} {
+			Expect(evtype.IsInformational()).To(BeFalse(), "%s", evtype)
+		}
+		for _, evtype := range []ContainerEventType{
+			ContainerCreated, ContainerRemoved, ContainerKilled,
+			ContainerOOMKilled, ContainerRestarting, ContainerStopped,
+		} {
+			Expect(evtype.IsInformational()).To(BeTrue(), "%s", evtype)
+		}
+	})
+
+})
@@ -0,0 +1,299 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// DefaultSocket is the default path of the Incus API socket.
+const DefaultSocket = "/var/lib/incus/unix.socket"
+
+// LXDSocket is the default path of the LXD API socket, when LXD has been
+// installed as a snap.
+const LXDSocket = "/var/snap/lxd/common/lxd/unix.socket"
+
+// Client is a minimal client for the Incus (and LXD) REST API, supporting only
+// the API operations required for watching system containers.
+type Client struct {
+	socket string       // path of the API unix socket.
+	client *http.Client // HTTP client, talking over the unix socket.
+}
+
+// DefaultHost returns the default Incus API endpoint: the API socket inside
+// the directory specified by the INCUS_DIR environment variable if set,
+// otherwise [DefaultSocket].
+func DefaultHost() string {
+	if dir := os.Getenv("INCUS_DIR"); dir != "" {
+		return filepath.Join(dir, "unix.socket")
+	}
+	return DefaultSocket
+}
+
+// NewClient returns a new Incus API client for the specified unix socket API
+// endpoint, in either "unix:///path" format or as a plain absolute path. If
+// socket is empty, [DefaultHost] is used instead. NewClient does not contact
+// the Incus service.
+func NewClient(socket string) (*Client, error) {
+	if socket == "" {
+		socket = DefaultHost()
+	}
+	sockpath := strings.TrimPrefix(socket, "unix://")
+	if !strings.HasPrefix(sockpath, "/") {
+		return nil, fmt.Errorf("unsupported Incus API endpoint %q", socket)
+	}
+	c := &Client{socket: sockpath}
+	c.client = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return c.dial(ctx)
+			},
+		},
+	}
+	return c, nil
+}
+
+// dial the Incus API unix socket.
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", c.socket)
+}
+
+// Socket returns the path of the API unix socket of this client.
+func (c *Client) Socket() string { return c.socket }
+
+// Close releases any idle connections to the Incus service.
+func (c *Client) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// APIError is an error response returned by the Incus API.
+type APIError struct {
+	StatusCode int    // error code, following HTTP status code semantics.
+	Message    string // error message, if any.
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Incus API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if the specified error is an [APIError] reporting a
+// non-existing instance.
+func IsNotFound(err error) bool {
+	var apierr *APIError
+	return errors.As(err, &apierr) && apierr.StatusCode == http.StatusNotFound
+}
+
+// response is the generic envelope of all Incus API responses.
+type response struct {
+	Type       string          `json:"type"` // "sync", "async", or "error".
+	StatusCode int             `json:"status_code"`
+	Error      string          `json:"error"`
+	ErrorCode  int             `json:"error_code"`
+	Metadata   json.RawMessage `json:"metadata"`
+}
+
+// get the specified Incus API resource and decodes the metadata of its
+// (synchronous) response into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	u := "http://incus" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var r response
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		if resp.StatusCode/100 != 2 {
+			return &APIError{StatusCode: resp.StatusCode, Message: resp.Status}
+		}
+		return err
+	}
+	if r.Type == "error" || resp.StatusCode/100 != 2 {
+		code := r.ErrorCode
+		if code == 0 {
+			code = resp.StatusCode
+		}
+		return &APIError{StatusCode: code, Message: r.Error}
+	}
+	return json.Unmarshal(r.Metadata, v)
+}
+
+// Server is the subset of the Incus server information of interest to us.
+type Server struct {
+	Environment struct {
+		ServerName    string `json:"server_name"`
+		Server        string `json:"server"` // "incus" or "lxd".
+		ServerVersion string `json:"server_version"`
+		ServerPid     int    `json:"server_pid"`
+	} `json:"environment"`
+}
+
+// Server returns the Incus server information.
+func (c *Client) Server(ctx context.Context) (*Server, error) {
+	var server Server
+	if err := c.get(ctx, "/1.0", nil, &server); err != nil {
+		return nil, err
+	}
+	return &server, nil
+}
+
+// Instance is the subset of the Incus instance information of interest to us.
+type Instance struct {
+	Name    string            `json:"name"`
+	Project string            `json:"project"`
+	Type    string            `json:"type"`   // "container" or "virtual-machine".
+	Status  string            `json:"status"` // such as "Running", "Frozen", "Stopped".
+	Config  map[string]string `json:"config"`
+	State   *InstanceState    `json:"state"` // only when listing with state.
+}
+
+// InstanceState is the subset of the Incus instance state information of
+// interest to us.
+type InstanceState struct {
+	Status string `json:"status"`
+	Pid    int    `json:"pid"`
+}
+
+// Instances returns the instances of all projects, including their states.
+func (c *Client) Instances(ctx context.Context) ([]Instance, error) {
+	var instances []Instance
+	if err := c.get(ctx, "/1.0/instances", url.Values{
+		"recursion":    {"2"},
+		"all-projects": {"true"},
+		"filter":       {"type eq container"},
+	}, &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// Instance returns the instance with the specified name in the specified
+// project, including its state.
+func (c *Client) Instance(ctx context.Context, project string, name string) (*Instance, error) {
+	query := url.Values{"project": {project}}
+	path := "/1.0/instances/" + url.PathEscape(name)
+	var instance Instance
+	if err := c.get(ctx, path, query, &instance); err != nil {
+		return nil, err
+	}
+	var state InstanceState
+	if err := c.get(ctx, path+"/state", query, &state); err != nil {
+		return nil, err
+	}
+	instance.State = &state
+	return &instance, nil
+}
+
+// Event is the subset of an Incus lifecycle event of interest to us.
+type Event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Project   string    `json:"project"`
+	Metadata  struct {
+		Action string `json:"action"` // such as "instance-started".
+		Source string `json:"source"` // such as "/1.0/instances/foo?project=bar".
+	} `json:"metadata"`
+}
+
+// Events streams the lifecycle events of all projects, using the Incus event
+// websocket. The event channel is closed when the event stream ends, after
+// sending any error to the buffered error channel.
+func (c *Client) Events(ctx context.Context) (<-chan Event, <-chan error) {
+	evs := make(chan Event)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(evs)
+		ws, err := c.websocket(ctx, "/1.0/events?"+url.Values{
+			"type":         {"lifecycle"},
+			"all-projects": {"true"},
+		}.Encode())
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer func() { _ = ws.Close() }()
+		// Unblock receiving from the websocket when the context gets
+		// cancelled.
+		stop := context.AfterFunc(ctx, func() { _ = ws.Close() })
+		defer stop()
+		for {
+			var ev Event
+			if err := websocket.JSON.Receive(ws, &ev); err != nil {
+				if ctx.Err() != nil {
+					err = ctx.Err()
+				} else if err == io.EOF {
+					err = io.ErrUnexpectedEOF // event streams never end on their own.
+				}
+				errs <- err
+				return
+			}
+			select {
+			case evs <- ev:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return evs, errs
+}
+
+// websocket connects to the specified Incus API websocket resource.
+func (c *Client) websocket(ctx context.Context, resource string) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig("ws://incus"+resource, "http://incus/")
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Don't get stuck in the websocket handshake when the context gets
+	// cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+	ws, err := websocket.NewClient(config, conn)
+	if err != nil {
+		_ = conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return ws, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/thediveo/whalewatcher/v2/test/mockingincus"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Incus API client", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	Context("API endpoints", func() {
+
+		It("determines the default API endpoint", func() {
+			GinkgoT().Setenv("INCUS_DIR", "")
+			Expect(DefaultHost()).To(Equal(DefaultSocket))
+			GinkgoT().Setenv("INCUS_DIR", "/var/lib/foo")
+			Expect(DefaultHost()).To(Equal("/var/lib/foo/unix.socket"))
+			c := Successful(NewClient(""))
+			defer func() { _ = c.Close() }()
+			Expect(c.Socket()).To(Equal("/var/lib/foo/unix.socket"))
+		})
+
+		DescribeTable("accepts valid endpoints",
+			func(socket string) {
+				c := Successful(NewClient(socket))
+				defer func() { _ = c.Close() }()
+				Expect(c.Socket()).To(Equal(LXDSocket))
+			},
+			Entry("unix socket URL", "unix://"+LXDSocket),
+			Entry("plain unix socket path", LXDSocket),
+		)
+
+		DescribeTable("rejects invalid endpoints",
+			func(socket string) {
+				Expect(NewClient(socket)).Error().To(HaveOccurred())
+			},
+			Entry("empty unix socket path", "unix://"),
+			Entry("TCP", "tcp://localhost:8443"),
+			Entry("relative path", "unix.socket"),
+		)
+
+	})
+
+	Context("talking to an Incus service", func() {
+
+		var mi *mockingincus.MockingIncus
+		var c *Client
+
+		BeforeEach(func() {
+			mi = Successful(mockingincus.New())
+			DeferCleanup(mi.Close)
+			c = Successful(NewClient("unix://" + mi.Socket()))
+			DeferCleanup(c.Close)
+		})
+
+		It("gets the server information", func(ctx context.Context) {
+			server := Successful(c.Server(ctx))
+			Expect(server.Environment.ServerName).To(Equal("mockinghost"))
+			Expect(server.Environment.ServerVersion).NotTo(BeEmpty())
+		})
+
+		It("reports API errors", func(ctx context.Context) {
+			_, err := c.Instance(ctx, "default", "foobar")
+			Expect(err).To(HaveOccurred())
+			Expect(IsNotFound(err)).To(BeTrue())
+			var apierr *APIError
+			Expect(errors.As(err, &apierr)).To(BeTrue())
+			Expect(apierr.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(apierr.Error()).To(ContainSubstring("Instance not found"))
+			Expect(IsNotFound(errors.New("D'OH!"))).To(BeFalse())
+		})
+
+		It("lists and gets instances", func(ctx context.Context) {
+			mi.AddInstance(mockingincus.MockedInstance{
+				Name: "foo", Project: "bar", PID: 42,
+				Config: map[string]string{"user.foo": "bar"},
+			})
+			Expect(c.Instances(ctx)).To(ConsistOf(And(
+				HaveField("Name", "foo"),
+				HaveField("Project", "bar"),
+				HaveField("State.Pid", 42))))
+			instance := Successful(c.Instance(ctx, "bar", "foo"))
+			Expect(instance.Type).To(Equal("container"))
+			Expect(instance.State.Pid).To(Equal(42))
+			Expect(instance.Config).To(HaveKeyWithValue("user.foo", "bar"))
+			Expect(c.Instance(ctx, "default", "foo")).Error().To(Satisfy(IsNotFound))
+		})
+
+		It("streams events until cancelled", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			evs, errs := c.Events(ctx)
+			Eventually(mi.Subscribers).Should(Equal(1))
+			mi.AddInstance(mockingincus.MockedInstance{Name: "foo", PID: 42})
+			Eventually(evs).Should(Receive(And(
+				HaveField("Type", "lifecycle"),
+				HaveField("Project", "default"),
+				HaveField("Metadata.Action", "instance-started"),
+				HaveField("Metadata.Source", "/1.0/instances/foo"))))
+			cancel()
+			Eventually(evs).Should(BeClosed())
+			Expect(<-errs).To(MatchError(context.Canceled))
+			Eventually(mi.Subscribers).Should(BeZero())
+		})
+
+		It("reports ending event streams", func(ctx context.Context) {
+			evs, errs := c.Events(ctx)
+			Eventually(mi.Subscribers).Should(Equal(1))
+			mi.Close()
+			Eventually(evs).Should(BeClosed())
+			Expect(<-errs).To(HaveOccurred())
+		})
+
+		It("fails to stream events from a missing service", func(ctx context.Context) {
+			mi.Close()
+			evs, errs := c.Events(ctx)
+			Eventually(evs).Should(BeClosed())
+			Expect(<-errs).To(HaveOccurred())
+		})
+
+	})
+
+})
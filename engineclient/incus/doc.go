@@ -0,0 +1,19 @@
+/*
+Package incus implements the Incus (and LXD) EngineClient for system
+containers, talking to the local Incus REST API over its unix socket. Only
+running and frozen instances of type "container" are considered, virtual
+machines are ignored.
+
+Incus projects map to whalewatcher composer projects, with the exception of the
+Incus "default" project, which maps to containers without any composer project.
+As instance names are unique only per Incus project, container IDs are in
+"project/name" format, whereas container names are the plain instance names.
+The Incus project name is additionally available via the [ProjectLabel] label,
+and the user-defined "user.*" instance configuration keys become container
+labels.
+
+As LXD's REST API is the same as the Incus REST API, the same EngineClient
+also works with LXD: in this case, use [WithEngineType] with [LXDType], and
+[LXDSocket] as the API endpoint.
+*/
+package incus
@@ -0,0 +1,305 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Type specifies this container engine's type identifier.
+const Type = "linuxcontainers.org/incus"
+
+// LXDType specifies the type identifier for LXD, when watching LXD instead of
+// Incus using [WithEngineType].
+const LXDType = "canonical.com/lxd"
+
+// DefaultProject is the name of the Incus default project; its instances
+// belong to no composer project.
+const DefaultProject = "default"
+
+// ProjectLabel is the name of the container label identifying the Incus
+// project an instance belongs to, including the default project.
+const ProjectLabel = "github.com/thediveo/whalewatcher/incus/project"
+
+// UserConfigPrefix is the prefix of the user-defined instance configuration
+// keys that become container labels.
+const UserConfigPrefix = "user."
+
+// IncusWatcher is an Incus EngineClient for interfacing the generic whale
+// watching with Incus (and LXD) services.
+type IncusWatcher struct {
+	pid        int                         // optional engine PID when known.
+	client     *Client                     // Incus API client.
+	packer     engineclient.RucksackPacker // optional Rucksack packer for app-specific container information.
+	enginetype string                      // allow overriding the Incus type for LXD.
+}
+
+// Make sure that the EngineClient interface is fully implemented.
+var _ (engineclient.EngineClient) = (*IncusWatcher)(nil)
+
+// NewIncusWatcher returns a new IncusWatcher using the specified Incus API
+// client; typically, you would want to use this lower-level constructor only
+// in unit tests and instead use watcher.incus.New instead in most use cases.
+func NewIncusWatcher(client *Client, opts ...NewOption) *IncusWatcher {
+	iw := &IncusWatcher{
+		client:     client,
+		enginetype: Type,
+	}
+	for _, opt := range opts {
+		opt(iw)
+	}
+	return iw
+}
+
+// NewOption represents options to NewIncusWatcher when creating new watchers
+// keeping eyes on Incus services.
+type NewOption func(*IncusWatcher)
+
+// WithPID sets the engine's PID when known.
+func WithPID(pid int) NewOption {
+	return func(iw *IncusWatcher) {
+		iw.pid = pid
+	}
+}
+
+// WithEngineType overrides the default “linuxcontainers.org/incus” engine
+// type, such as when watching LXD instead of Incus.
+func WithEngineType(enginetype string) NewOption {
+	return func(iw *IncusWatcher) {
+		iw.enginetype = enginetype
+	}
+}
+
+// WithRucksackPacker sets the Rucksack packer that adds application-specific
+// container information based on the inspected container data. The specified
+// Rucksack packer gets passed the inspection data in form of an *Instance.
+// Please consider using [WithTypedRucksackPacker] instead.
+func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
+	return func(iw *IncusWatcher) {
+		iw.packer = packer
+	}
+}
+
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[*Instance]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
+// ID returns the (more or less) unique engine identifier, which is the Incus
+// server name.
+func (iw *IncusWatcher) ID(ctx context.Context) string {
+	server, err := iw.client.Server(ctx)
+	if err != nil {
+		return ""
+	}
+	return server.Environment.ServerName
+}
+
+// Type returns the type identifier for this container engine.
+func (iw *IncusWatcher) Type() string { return iw.enginetype }
+
+// Version information about the engine.
+func (iw *IncusWatcher) Version(ctx context.Context) string {
+	server, err := iw.client.Server(ctx)
+	if err != nil {
+		return ""
+	}
+	return server.Environment.ServerVersion
+}
+
+// API returns the container engine API path.
+func (iw *IncusWatcher) API() string { return iw.client.Socket() }
+
+// PID returns the container engine PID, when known.
+func (iw *IncusWatcher) PID() int { return iw.pid }
+
+// Client returns the underlying engine client (engine-specific).
+func (iw *IncusWatcher) Client() any { return iw.client }
+
+// Close cleans up and release any engine client resources, if necessary.
+func (iw *IncusWatcher) Close() {
+	_ = iw.client.Close()
+}
+
+// List all the currently alive and kicking system containers, but do not list
+// any containers without any processes, nor any virtual machines.
+func (iw *IncusWatcher) List(ctx context.Context) ([]*whalewatcher.Container, error) {
+	instances, err := iw.client.Instances(ctx)
+	if err != nil {
+		return nil, err // list? what list??
+	}
+	alives := make([]*whalewatcher.Container, 0, len(instances))
+	for idx := range instances {
+		alive, err := iw.container(&instances[idx])
+		if err != nil {
+			continue
+		}
+		alives = append(alives, alive)
+	}
+	return alives, nil
+}
+
+// Inspect (only) those container details of interest to us, given the ID of a
+// container in "project/name" format; a plain name refers to an instance in
+// the default project. If inspection fails, it returns an error instead.
+func (iw *IncusWatcher) Inspect(ctx context.Context, nameorid string) (*whalewatcher.Container, error) {
+	project, name, ok := strings.Cut(nameorid, "/")
+	if !ok {
+		project, name = DefaultProject, nameorid
+	}
+	instance, err := iw.client.Instance(ctx, project, name)
+	if err != nil {
+		return nil, err
+	}
+	return iw.container(instance)
+}
+
+// container returns the container details for the specified instance, or an
+// error if the instance either isn't a container or has no initial process.
+func (iw *IncusWatcher) container(instance *Instance) (*whalewatcher.Container, error) {
+	id := ContainerID(instance.Project, instance.Name)
+	if instance.Type != "" && instance.Type != "container" {
+		return nil, engineclient.NewProcesslessContainerError(id, "Incus")
+	}
+	if instance.State == nil || instance.State.Pid == 0 {
+		return nil, engineclient.NewProcesslessContainerError(id, "Incus")
+	}
+	project := instance.Project
+	if project == "" {
+		project = DefaultProject
+	}
+	labels := map[string]string{
+		ProjectLabel: project,
+	}
+	for key, value := range instance.Config {
+		if strings.HasPrefix(key, UserConfigPrefix) {
+			labels[key] = value
+		}
+	}
+	if project == DefaultProject {
+		project = ""
+	}
+	cntr := &whalewatcher.Container{
+		ID:      id,
+		Name:    instance.Name,
+		Labels:  labels,
+		PID:     instance.State.Pid,
+		Project: project,
+		Paused:  instance.State.Status == "Frozen",
+	}
+	// If someone wants to keep more details, let them pack it into the Rucksack
+	// of the container description.
+	if iw.packer != nil {
+		iw.packer.Pack(cntr, instance)
+	}
+	return cntr, nil
+}
+
+// ContainerID returns the container ID in "project/name" format for the
+// specified Incus project and instance names.
+func ContainerID(project, name string) string {
+	if project == "" {
+		project = DefaultProject
+	}
+	return project + "/" + name
+}
+
+// LifecycleEvents streams container engine events, limited just to those events
+// in the lifecycle of containers getting born (=alive, as opposed to, say,
+// "conceived") and die. Please note that Incus lifecycle events don't tell
+// containers and virtual machines apart, so the latter are only weeded out
+// when inspecting them.
+func (iw *IncusWatcher) LifecycleEvents(ctx context.Context) (<-chan engineclient.ContainerEvent, <-chan error) {
+	cntreventstream := make(chan engineclient.ContainerEvent)
+	cntrerrstream := make(chan error, 1)
+
+	go func() {
+		defer close(cntrerrstream)
+		evs, errs := iw.client.Events(ctx)
+		for ev := range evs {
+			id, ok := instanceID(ev.Metadata.Source, ev.Project)
+			if !ok {
+				continue
+			}
+			project := ev.Project
+			if project == DefaultProject {
+				project = ""
+			}
+			var types []engineclient.ContainerEventType
+			switch ev.Metadata.Action {
+			case "instance-started":
+				types = []engineclient.ContainerEventType{engineclient.ContainerStarted}
+			case "instance-stopped", "instance-shutdown":
+				types = []engineclient.ContainerEventType{engineclient.ContainerExited}
+			case "instance-restarted":
+				// a restarted container has a new initial process, so we
+				// first need to get rid of the old container details.
+				types = []engineclient.ContainerEventType{
+					engineclient.ContainerExited, engineclient.ContainerStarted}
+			case "instance-paused":
+				types = []engineclient.ContainerEventType{engineclient.ContainerPaused}
+			case "instance-resumed":
+				types = []engineclient.ContainerEventType{engineclient.ContainerUnpaused}
+			default:
+				continue
+			}
+			for _, evtype := range types {
+				select {
+				case cntreventstream <- engineclient.ContainerEvent{
+					Type:      evtype,
+					ID:        id,
+					Project:   project,
+					Timestamp: ev.Timestamp,
+				}:
+				case <-ctx.Done():
+				}
+			}
+		}
+		err := <-errs
+		// Let a cancelled context take priority over any other event stream
+		// error, as the latter is usually just a consequence.
+		if ctx.Err() == context.Canceled {
+			err = ctx.Err()
+		}
+		cntrerrstream <- err
+	}()
+
+	return cntreventstream, cntrerrstream
+}
+
+// instanceID returns the container ID for the instance referenced by the
+// specified event source, such as "/1.0/instances/foo?project=bar". If the
+// source doesn't specify a project, the event's project applies.
+func instanceID(source string, project string) (string, bool) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return "", false
+	}
+	name, ok := strings.CutPrefix(u.Path, "/1.0/instances/")
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+	if p := u.Query().Get("project"); p != "" {
+		project = p
+	}
+	return ContainerID(project, name), true
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"context"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+	. "github.com/thediveo/whalewatcher/v2/test/matcher"
+	"github.com/thediveo/whalewatcher/v2/test/mockingincus"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var (
+	furiousFuruncle = mockingincus.MockedInstance{
+		Name:   "furious-furuncle",
+		PID:    666,
+		Config: map[string]string{"user.foo": "bar", "image.os": "debian"},
+	}
+
+	projectedPodder = mockingincus.MockedInstance{
+		Name:    "podder",
+		Project: "testproject",
+		PID:     4242,
+	}
+
+	virtualVictim = mockingincus.MockedInstance{
+		Name: "virtual-victim",
+		Type: "virtual-machine",
+		PID:  1234,
+	}
+
+	deadDummy = mockingincus.MockedInstance{
+		Name: "dead-dummy",
+	}
+)
+
+var _ = Describe("incus engineclient", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	var mi *mockingincus.MockingIncus
+	var ec *IncusWatcher
+
+	BeforeEach(func() {
+		mi = Successful(mockingincus.New())
+		DeferCleanup(mi.Close)
+		ec = NewIncusWatcher(Successful(NewClient(mi.Socket())), WithPID(123456))
+		DeferCleanup(ec.Close)
+		Expect(ec.PID()).To(Equal(123456))
+		mi.AddInstance(furiousFuruncle)
+	})
+
+	It("has engine type ID, API path, and client", func() {
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.API()).To(Equal(mi.Socket()))
+		Expect(ec.Client()).To(BeIdenticalTo(ec.client))
+	})
+
+	It("can change its type", func() {
+		WithEngineType(LXDType)(ec)
+		Expect(ec.Type()).To(Equal(LXDType))
+	})
+
+	It("has an ID and version", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		Expect(ec.ID(ctx)).To(Equal("mockinghost"))
+		Expect(ec.Version(ctx)).NotTo(BeEmpty())
+		cancel()
+		Expect(ec.ID(ctx)).To(BeZero())
+		Expect(ec.Version(ctx)).To(BeZero())
+	})
+
+	It("cannot inspect dead, missing, or virtual machine instances", func(ctx context.Context) {
+		mi.AddInstance(deadDummy)
+		mi.AddInstance(virtualVictim)
+		Expect(ec.Inspect(ctx, deadDummy.Name)).Error().To(Satisfy(engineclient.IsProcesslessContainer))
+		Expect(ec.Inspect(ctx, virtualVictim.Name)).Error().To(Satisfy(engineclient.IsProcesslessContainer))
+		Expect(ec.Inspect(ctx, "foobar")).Error().To(Satisfy(IsNotFound))
+	})
+
+	It("inspects a furuncle", func(ctx context.Context) {
+		for _, nameorid := range []string{furiousFuruncle.Name, "default/" + furiousFuruncle.Name} {
+			cntr := Successful(ec.Inspect(ctx, nameorid))
+			Expect(cntr).To(And(
+				HaveID("default/"+furiousFuruncle.Name),
+				HaveName(furiousFuruncle.Name),
+				HaveProject(""),
+			))
+			Expect(cntr.PID).To(Equal(furiousFuruncle.PID))
+			Expect(cntr.Labels).To(Equal(map[string]string{
+				ProjectLabel: DefaultProject,
+				"user.foo":   "bar",
+			}))
+		}
+	})
+
+	It("maps Incus projects to projects", func(ctx context.Context) {
+		mi.AddInstance(projectedPodder)
+		cntr := Successful(ec.Inspect(ctx, "testproject/podder"))
+		Expect(cntr).To(And(
+			HaveID("testproject/podder"),
+			HaveName("podder"),
+			HaveProject("testproject"),
+		))
+		Expect(cntr.Labels).To(HaveKeyWithValue(ProjectLabel, "testproject"))
+	})
+
+	It("inspects a frozen instance", func(ctx context.Context) {
+		mi.PauseInstance("", furiousFuruncle.Name)
+		Expect(ec.Inspect(ctx, furiousFuruncle.Name)).To(HaveField("Paused", BeTrue()))
+	})
+
+	It("inspects using a typed rucksack packer", func(ctx context.Context) {
+		WithTypedRucksackPacker(engineclient.RucksackPackerFunc[*Instance](
+			func(container *whalewatcher.Container, inspection *Instance) {
+				container.Rucksack = inspection.Config["image.os"]
+			}))(ec)
+		cntr := Successful(ec.Inspect(ctx, furiousFuruncle.Name))
+		Expect(whalewatcher.RucksackOf[string](cntr)).To(Equal("debian"))
+	})
+
+	It("lists containers", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		mi.AddInstance(projectedPodder)
+		mi.AddInstance(virtualVictim)
+		mi.AddInstance(deadDummy)
+		Expect(ec.List(ctx)).To(ConsistOf(
+			And(HaveID("default/"+furiousFuruncle.Name), HaveProject("")),
+			And(HaveID("testproject/podder"), HaveProject("testproject")),
+		))
+
+		cancel()
+		Expect(ec.List(ctx)).Error().To(HaveOccurred())
+	})
+
+	It("watches containers come and go", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Expect(evs).NotTo(BeNil())
+		Expect(errs).NotTo(BeNil())
+		Eventually(mi.Subscribers).Should(Equal(1))
+
+		Consistently(evs).ShouldNot(Receive())
+		Consistently(errs).ShouldNot(Receive())
+
+		By("adding a new container")
+		mi.AddInstance(projectedPodder)
+		Eventually(evs).Should(Receive(And(
+			HaveTimestamp(Not(BeZero())),
+			HaveID("testproject/podder"),
+			HaveEventType(engineclient.ContainerStarted),
+			HaveProject("testproject"),
+		)))
+
+		By("pausing the container")
+		mi.PauseInstance("testproject", "podder")
+		Eventually(evs).Should(Receive(And(
+			HaveID("testproject/podder"),
+			HaveEventType(engineclient.ContainerPaused),
+		)))
+
+		By("resuming the container")
+		mi.ResumeInstance("testproject", "podder")
+		Eventually(evs).Should(Receive(And(
+			HaveID("testproject/podder"),
+			HaveEventType(engineclient.ContainerUnpaused),
+		)))
+
+		By("restarting the container")
+		mi.RestartInstance("testproject", "podder", 4343)
+		Eventually(evs).Should(Receive(And(
+			HaveID("testproject/podder"),
+			HaveEventType(engineclient.ContainerExited),
+		)))
+		Eventually(evs).Should(Receive(And(
+			HaveID("testproject/podder"),
+			HaveEventType(engineclient.ContainerStarted),
+		)))
+
+		By("stopping a container in the default project")
+		mi.StopInstance("", furiousFuruncle.Name)
+		Eventually(evs).Should(Receive(And(
+			HaveID("default/"+furiousFuruncle.Name),
+			HaveEventType(engineclient.ContainerExited),
+			HaveProject(""),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	DescribeTable("deriving container IDs from event sources",
+		func(source, project, expected string) {
+			id, ok := instanceID(source, project)
+			if expected == "" {
+				Expect(ok).To(BeFalse())
+				return
+			}
+			Expect(ok).To(BeTrue())
+			Expect(id).To(Equal(expected))
+		},
+		Entry("default project", "/1.0/instances/foo", "default", "default/foo"),
+		Entry("unspecified project", "/1.0/instances/foo", "", "default/foo"),
+		Entry("project from source", "/1.0/instances/foo?project=bar", "default", "bar/foo"),
+		Entry("escaped name", "/1.0/instances/f%6Fo", "bar", "bar/foo"),
+		Entry("not an instance", "/1.0/storage-pools/foo", "default", ""),
+		Entry("instance sub-resource", "/1.0/instances/foo/snapshots/bar", "default", ""),
+		Entry("invalid source", "%", "default", ""),
+	)
+
+})
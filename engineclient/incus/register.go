@@ -0,0 +1,65 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"net/url"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for Incus services, such as
+// "incus:///var/lib/incus/unix.socket". With an empty path, [DefaultHost]
+// applies. The only query parameter is "pid".
+const Scheme = "incus"
+
+// LXDScheme is the name of the engine URL scheme for LXD services, such as
+// "lxd:///var/snap/lxd/common/lxd/unix.socket". With an empty path,
+// [LXDSocket] applies. The only query parameter is "pid".
+const LXDScheme = "lxd"
+
+func init() {
+	engineclient.Register(Scheme, open)
+	engineclient.Register(LXDScheme, open)
+}
+
+// open returns a new IncusWatcher configured by the specified engine URL,
+// watching either Incus or LXD depending on the URL's scheme.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	sockpath, err := engineclient.SocketPath(u)
+	if err != nil {
+		return nil, err
+	}
+	opts := []NewOption{WithPID(pid)}
+	if name, _ := engineclient.SplitScheme(u.Scheme); name == LXDScheme {
+		if sockpath == "" {
+			sockpath = LXDSocket
+		}
+		opts = append(opts, WithEngineType(LXDType))
+	}
+	client, err := NewClient(sockpath)
+	if err != nil {
+		return nil, err
+	}
+	return NewIncusWatcher(client, opts...), nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Incus engine URLs", func() {
+
+	It("opens engine URLs", func() {
+		ec := Successful(engineclient.Open("incus:///run/foo.socket?pid=42"))
+		defer ec.Close()
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.PID()).To(Equal(42))
+		Expect(ec.API()).To(Equal("/run/foo.socket"))
+
+		ec = Successful(engineclient.Open("lxd+unix://"))
+		defer ec.Close()
+		Expect(ec.Type()).To(Equal(LXDType))
+		Expect(ec.API()).To(Equal(LXDSocket))
+	})
+
+	It("rejects invalid engine URLs", func() {
+		Expect(engineclient.Open("incus+tcp://localhost:8443")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("incus:///run/foo.socket?project=default")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("lxd:///run/foo.socket?pid=foo")).Error().To(HaveOccurred())
+	})
+
+})
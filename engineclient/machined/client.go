@@ -0,0 +1,260 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machined
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Service is the well-known D-Bus name of the systemd-machined service.
+const Service = "org.freedesktop.machine1"
+
+// ManagerPath is the D-Bus object path of the systemd-machined manager.
+const ManagerPath = dbus.ObjectPath("/org/freedesktop/machine1")
+
+// ManagerInterface is the D-Bus interface of the systemd-machined manager.
+const ManagerInterface = "org.freedesktop.machine1.Manager"
+
+// MachineInterface is the D-Bus interface of systemd-machined machine objects.
+const MachineInterface = "org.freedesktop.machine1.Machine"
+
+// The D-Bus errors signalling missing machines.
+const (
+	errNoSuchMachine = "org.freedesktop.machine1.NoSuchMachine"
+	errUnknownObject = "org.freedesktop.DBus.Error.UnknownObject"
+)
+
+// ErrClosed is returned when the D-Bus connection has been closed while
+// streaming events.
+var ErrClosed = errors.New("D-Bus connection closed")
+
+// Client is a minimal client for the systemd-machined D-Bus API, supporting
+// only the API operations required for watching containers.
+type Client struct {
+	address string     // D-Bus address as passed in; empty for the system bus.
+	conn    *dbus.Conn // D-Bus connection.
+}
+
+// NewClient returns a new systemd-machined client, connected to the D-Bus
+// message bus with the specified address, such as
+// "unix:path=/run/dbus/system_bus_socket". If address is empty, NewClient
+// connects to the system bus.
+func NewClient(address string) (*Client, error) {
+	var conn *dbus.Conn
+	var err error
+	if address == "" {
+		conn, err = dbus.ConnectSystemBus()
+	} else {
+		conn, err = dbus.Connect(address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Client{address: address, conn: conn}, nil
+}
+
+// Address returns the D-Bus address of this client, or an empty string for the
+// system bus.
+func (c *Client) Address() string { return c.address }
+
+// Conn returns the underlying D-Bus connection.
+func (c *Client) Conn() *dbus.Conn { return c.conn }
+
+// Close the D-Bus connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// IsNotFound returns true if the specified error is a D-Bus error reporting a
+// non-existing machine.
+func IsNotFound(err error) bool {
+	var dbuserr dbus.Error
+	return errors.As(err, &dbuserr) &&
+		(dbuserr.Name == errNoSuchMachine || dbuserr.Name == errUnknownObject)
+}
+
+// MachineID returns the machine ID of the host systemd-machined is running on.
+func (c *Client) MachineID(ctx context.Context) (string, error) {
+	var id string
+	err := c.conn.Object(Service, ManagerPath).
+		CallWithContext(ctx, "org.freedesktop.DBus.Peer.GetMachineId", 0).
+		Store(&id)
+	return id, err
+}
+
+// Version returns the systemd version.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var version string
+	err := c.conn.Object("org.freedesktop.systemd1", "/org/freedesktop/systemd1").
+		CallWithContext(ctx, "org.freedesktop.DBus.Properties.Get", 0,
+			"org.freedesktop.systemd1.Manager", "Version").
+		Store(&version)
+	return version, err
+}
+
+// ListedMachine is a machine as listed by systemd-machined.
+type ListedMachine struct {
+	Name    string
+	Class   string // such as "container", "vm", or "host".
+	Service string
+	Path    dbus.ObjectPath
+}
+
+// Machines returns the machines currently registered with systemd-machined.
+func (c *Client) Machines(ctx context.Context) ([]ListedMachine, error) {
+	var machines []ListedMachine
+	if err := c.conn.Object(Service, ManagerPath).
+		CallWithContext(ctx, ManagerInterface+".ListMachines", 0).
+		Store(&machines); err != nil {
+		return nil, err
+	}
+	return machines, nil
+}
+
+// Machine is the subset of the systemd-machined machine information of
+// interest to us. Properties contains all properties of the machine object.
+type Machine struct {
+	Name          string
+	Class         string
+	Service       string
+	Unit          string
+	Leader        uint32 // PID of the machine's leader process.
+	RootDirectory string
+	State         string // such as "opening", "running", or "closing".
+	Path          dbus.ObjectPath
+
+	Properties map[string]dbus.Variant
+}
+
+// Machine returns the information about the machine with the specified name.
+func (c *Client) Machine(ctx context.Context, name string) (*Machine, error) {
+	var path dbus.ObjectPath
+	if err := c.conn.Object(Service, ManagerPath).
+		CallWithContext(ctx, ManagerInterface+".GetMachine", 0, name).
+		Store(&path); err != nil {
+		return nil, err
+	}
+	m := &Machine{Path: path}
+	if err := c.conn.Object(Service, path).
+		CallWithContext(ctx, "org.freedesktop.DBus.Properties.GetAll", 0, MachineInterface).
+		Store(&m.Properties); err != nil {
+		return nil, err
+	}
+	property(m.Properties, "Name", &m.Name)
+	property(m.Properties, "Class", &m.Class)
+	property(m.Properties, "Service", &m.Service)
+	property(m.Properties, "Unit", &m.Unit)
+	property(m.Properties, "Leader", &m.Leader)
+	property(m.Properties, "RootDirectory", &m.RootDirectory)
+	property(m.Properties, "State", &m.State)
+	return m, nil
+}
+
+// property stores the value of the named property in v if the property exists
+// and is of the correct type; otherwise, v is left untouched.
+func property[T any](props map[string]dbus.Variant, name string, v *T) {
+	if value, ok := props[name].Value().(T); ok {
+		*v = value
+	}
+}
+
+// Event is a machine lifecycle signal.
+type Event struct {
+	Member string // either "MachineNew" or "MachineRemoved".
+	Name   string // machine name.
+	Path   dbus.ObjectPath
+}
+
+// Events streams the machine lifecycle signals. The event channel is closed
+// when the event stream ends, after sending any error to the buffered error
+// channel. When the D-Bus connection gets closed, the error is always
+// [ErrClosed], regardless of whether the connection was closed before, while,
+// or after subscribing to the signals.
+func (c *Client) Events(ctx context.Context) (<-chan Event, <-chan error) {
+	evs := make(chan Event)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(evs)
+		sigs := make(chan *dbus.Signal, 16)
+		c.conn.Signal(sigs)
+		defer c.conn.RemoveSignal(sigs)
+		match := []dbus.MatchOption{
+			dbus.WithMatchSender(Service),
+			dbus.WithMatchObjectPath(ManagerPath),
+			dbus.WithMatchInterface(ManagerInterface),
+		}
+		if err := c.conn.AddMatchSignalContext(ctx, match...); err != nil {
+			errs <- c.closedErr(err)
+			return
+		}
+		defer func() { _ = c.conn.RemoveMatchSignal(match...) }()
+		// Registering our signal channel after the connection has been closed
+		// is silently ignored, so our channel would never get closed. We thus
+		// additionally watch the connection's context, which gets cancelled
+		// when closing the connection.
+		closed := c.conn.Context().Done()
+		for {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			case <-closed:
+				errs <- ErrClosed
+				return
+			case sig, ok := <-sigs:
+				if !ok {
+					errs <- ErrClosed
+					return
+				}
+				if sig.Path != ManagerPath || len(sig.Body) != 2 {
+					continue
+				}
+				ev := Event{}
+				switch sig.Name {
+				case ManagerInterface + ".MachineNew":
+					ev.Member = "MachineNew"
+				case ManagerInterface + ".MachineRemoved":
+					ev.Member = "MachineRemoved"
+				default:
+					continue
+				}
+				ev.Name, _ = sig.Body[0].(string)
+				ev.Path, _ = sig.Body[1].(dbus.ObjectPath)
+				select {
+				case evs <- ev:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				case <-closed:
+					errs <- ErrClosed
+					return
+				}
+			}
+		}
+	}()
+	return evs, errs
+}
+
+// closedErr returns [ErrClosed] if the D-Bus connection has been closed,
+// otherwise the specified error.
+func (c *Client) closedErr(err error) error {
+	if errors.Is(err, dbus.ErrClosed) || !c.conn.Connected() {
+		return ErrClosed
+	}
+	return err
+}
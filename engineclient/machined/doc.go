@@ -0,0 +1,16 @@
+/*
+Package machined implements the systemd-machined EngineClient, talking to the
+org.freedesktop.machine1 service over D-Bus. It tracks the containers
+registered with systemd-machined, such as systemd-nspawn containers, but
+ignores virtual machines as well as the host itself.
+
+Machines are identified by their machine names, which systemd-machined keeps
+unique; the container IDs and names are thus the same. As systemd-machined has
+no notion of composer projects, all machines belong to no composer project.
+The machine class, as well as the service and systemd unit of a machine become
+the container labels [ClassLabel], [ServiceLabel], and [UnitLabel].
+
+systemd-machined emits only MachineNew and MachineRemoved signals, so there
+are only container start and exit lifecycle events, but no pause events.
+*/
+package machined
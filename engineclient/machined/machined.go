@@ -0,0 +1,232 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machined
+
+import (
+	"context"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Type specifies this container engine's type identifier.
+const Type = "systemd.io/machined"
+
+// ContainerClass is the systemd-machined class of container machines.
+const ContainerClass = "container"
+
+// ClassLabel is the name of the container label identifying the
+// systemd-machined class of a machine, which is always "container".
+const ClassLabel = "github.com/thediveo/whalewatcher/machined/class"
+
+// ServiceLabel is the name of the container label identifying the service
+// that registered a machine, such as "systemd-nspawn".
+const ServiceLabel = "github.com/thediveo/whalewatcher/machined/service"
+
+// UnitLabel is the name of the container label identifying the systemd unit
+// of a machine, such as "systemd-nspawn@foo.service".
+const UnitLabel = "github.com/thediveo/whalewatcher/machined/unit"
+
+// MachinedWatcher is a systemd-machined EngineClient for interfacing the
+// generic whale watching with systemd-machined.
+type MachinedWatcher struct {
+	pid    int                         // optional engine PID when known.
+	client *Client                     // systemd-machined D-Bus client.
+	packer engineclient.RucksackPacker // optional Rucksack packer for app-specific container information.
+}
+
+// Make sure that the EngineClient interface is fully implemented.
+var _ (engineclient.EngineClient) = (*MachinedWatcher)(nil)
+
+// NewMachinedWatcher returns a new MachinedWatcher using the specified
+// systemd-machined client; typically, you would want to use this lower-level
+// constructor only in unit tests and instead use watcher.machined.New instead
+// in most use cases.
+func NewMachinedWatcher(client *Client, opts ...NewOption) *MachinedWatcher {
+	mw := &MachinedWatcher{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(mw)
+	}
+	return mw
+}
+
+// NewOption represents options to NewMachinedWatcher when creating new
+// watchers keeping eyes on systemd-machined.
+type NewOption func(*MachinedWatcher)
+
+// WithPID sets the engine's PID when known.
+func WithPID(pid int) NewOption {
+	return func(mw *MachinedWatcher) {
+		mw.pid = pid
+	}
+}
+
+// WithRucksackPacker sets the Rucksack packer that adds application-specific
+// container information based on the inspected container data. The specified
+// Rucksack packer gets passed the inspection data in form of a *Machine.
+// Please consider using [WithTypedRucksackPacker] instead.
+func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
+	return func(mw *MachinedWatcher) {
+		mw.packer = packer
+	}
+}
+
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[*Machine]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
+// ID returns the (more or less) unique engine identifier, which is the machine
+// ID of the host systemd-machined is running on.
+func (mw *MachinedWatcher) ID(ctx context.Context) string {
+	id, err := mw.client.MachineID(ctx)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// Type returns the type identifier for this container engine.
+func (mw *MachinedWatcher) Type() string { return Type }
+
+// Version information about the engine, that is, the systemd version.
+func (mw *MachinedWatcher) Version(ctx context.Context) string {
+	version, err := mw.client.Version(ctx)
+	if err != nil {
+		return ""
+	}
+	return version
+}
+
+// API returns the container engine API path, that is, the D-Bus address; it is
+// empty for the system bus.
+func (mw *MachinedWatcher) API() string { return mw.client.Address() }
+
+// PID returns the container engine PID, when known.
+func (mw *MachinedWatcher) PID() int { return mw.pid }
+
+// Client returns the underlying engine client (engine-specific).
+func (mw *MachinedWatcher) Client() any { return mw.client }
+
+// Close cleans up and release any engine client resources, if necessary.
+func (mw *MachinedWatcher) Close() {
+	_ = mw.client.Close()
+}
+
+// List all the currently alive and kicking container machines, but do not
+// list any virtual machines nor the host.
+func (mw *MachinedWatcher) List(ctx context.Context) ([]*whalewatcher.Container, error) {
+	machines, err := mw.client.Machines(ctx)
+	if err != nil {
+		return nil, err // list? what list??
+	}
+	alives := make([]*whalewatcher.Container, 0, len(machines))
+	for _, machine := range machines {
+		if machine.Class != ContainerClass {
+			continue
+		}
+		alive, err := mw.Inspect(ctx, machine.Name)
+		if err != nil {
+			// silently ignore missing machines that have gone since the list
+			// was prepared, but abort on severe problems in order to not keep
+			// this running for too long unnecessarily.
+			if !engineclient.IsProcesslessContainer(err) && !IsNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+		alives = append(alives, alive)
+	}
+	return alives, nil
+}
+
+// Inspect (only) those container details of interest to us, given the name of
+// a machine. If inspection fails, it returns an error instead; virtual
+// machines are reported as process-less containers.
+func (mw *MachinedWatcher) Inspect(ctx context.Context, nameorid string) (*whalewatcher.Container, error) {
+	machine, err := mw.client.Machine(ctx, nameorid)
+	if err != nil {
+		return nil, err
+	}
+	if machine.Class != ContainerClass || machine.Leader == 0 {
+		return nil, engineclient.NewProcesslessContainerError(nameorid, "machined")
+	}
+	cntr := &whalewatcher.Container{
+		ID:   machine.Name,
+		Name: machine.Name,
+		Labels: map[string]string{
+			ClassLabel:   machine.Class,
+			ServiceLabel: machine.Service,
+			UnitLabel:    machine.Unit,
+		},
+		PID: int(machine.Leader),
+	}
+	// If someone wants to keep more details, let them pack it into the Rucksack
+	// of the container description.
+	if mw.packer != nil {
+		mw.packer.Pack(cntr, machine)
+	}
+	return cntr, nil
+}
+
+// LifecycleEvents streams container engine events, limited just to those events
+// in the lifecycle of containers getting born (=alive, as opposed to, say,
+// "conceived") and die. As systemd-machined signals don't carry timestamps,
+// events are timestamped upon reception.
+func (mw *MachinedWatcher) LifecycleEvents(ctx context.Context) (<-chan engineclient.ContainerEvent, <-chan error) {
+	cntreventstream := make(chan engineclient.ContainerEvent)
+	cntrerrstream := make(chan error, 1)
+
+	go func() {
+		defer close(cntrerrstream)
+		// Don't block forever on passing on events when the D-Bus connection
+		// went away, but instead report the loss of the connection.
+		closed := mw.client.Conn().Context().Done()
+		evs, errs := mw.client.Events(ctx)
+		for ev := range evs {
+			cntrev := engineclient.ContainerEvent{
+				Timestamp: time.Now(),
+				ID:        ev.Name,
+			}
+			switch ev.Member {
+			case "MachineNew":
+				cntrev.Type = engineclient.ContainerStarted
+			case "MachineRemoved":
+				cntrev.Type = engineclient.ContainerExited
+			default:
+				continue
+			}
+			select {
+			case cntreventstream <- cntrev:
+			case <-ctx.Done():
+			case <-closed:
+			}
+		}
+		err := <-errs
+		// Let a cancelled context take priority over any other event stream
+		// error, as the latter is usually just a consequence.
+		if ctx.Err() == context.Canceled {
+			err = ctx.Err()
+		}
+		cntrerrstream <- err
+	}()
+
+	return cntreventstream, cntrerrstream
+}
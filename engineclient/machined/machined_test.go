@@ -0,0 +1,216 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machined
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+	. "github.com/thediveo/whalewatcher/v2/test/matcher"
+	"github.com/thediveo/whalewatcher/v2/test/mockingmachined"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var (
+	nervousNspawn = mockingmachined.MockedMachine{
+		Name:          "nervous-nspawn",
+		Service:       "systemd-nspawn",
+		Unit:          "systemd-nspawn@nervous-nspawn.service",
+		Leader:        666,
+		RootDirectory: "/var/lib/machines/nervous-nspawn",
+	}
+
+	virtualVictim = mockingmachined.MockedMachine{
+		Name:    "virtual-victim",
+		Class:   "vm",
+		Service: "qemu",
+		Leader:  1234,
+	}
+
+	hostHusk = mockingmachined.MockedMachine{
+		Name:   ".host",
+		Class:  "host",
+		Leader: 1,
+	}
+)
+
+// newMockingMachined returns a new mock systemd-machined service, skipping
+// the current spec if dbus-daemon isn't available.
+func newMockingMachined() *mockingmachined.MockingMachined {
+	GinkgoHelper()
+	mm, err := mockingmachined.New()
+	if errors.Is(err, mockingmachined.ErrNoDBusDaemon) {
+		Skip("needs dbus-daemon")
+	}
+	Expect(err).NotTo(HaveOccurred())
+	DeferCleanup(mm.Close)
+	return mm
+}
+
+var _ = Describe("machined engineclient", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	var mm *mockingmachined.MockingMachined
+	var ec *MachinedWatcher
+
+	BeforeEach(func() {
+		mm = newMockingMachined()
+		ec = NewMachinedWatcher(Successful(NewClient(mm.Address())), WithPID(123456))
+		DeferCleanup(ec.Close)
+		Expect(ec.PID()).To(Equal(123456))
+		mm.AddMachine(hostHusk)
+		mm.AddMachine(nervousNspawn)
+	})
+
+	It("has engine type ID, API path, and client", func() {
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.API()).To(Equal(mm.Address()))
+		Expect(ec.Client()).To(BeIdenticalTo(ec.client))
+		Expect(ec.client.Conn()).NotTo(BeNil())
+	})
+
+	It("reports D-Bus connection failures", func() {
+		Expect(NewClient("unix:path=/nowhere/bus")).Error().To(HaveOccurred())
+	})
+
+	It("has an ID and version", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		Expect(ec.ID(ctx)).To(MatchRegexp(`^[0-9a-f]{32}$`))
+		Expect(ec.Version(ctx)).To(Equal(mockingmachined.Version))
+		cancel()
+		Expect(ec.ID(ctx)).To(BeZero())
+		Expect(ec.Version(ctx)).To(BeZero())
+	})
+
+	It("cannot inspect missing, virtual, or host machines", func(ctx context.Context) {
+		mm.AddMachine(virtualVictim)
+		Expect(ec.Inspect(ctx, virtualVictim.Name)).Error().To(Satisfy(engineclient.IsProcesslessContainer))
+		Expect(ec.Inspect(ctx, hostHusk.Name)).Error().To(Satisfy(engineclient.IsProcesslessContainer))
+		Expect(ec.Inspect(ctx, "foobar")).Error().To(Satisfy(IsNotFound))
+		Expect(IsNotFound(errors.New("D'OH!"))).To(BeFalse())
+	})
+
+	It("inspects a nervous nspawn", func(ctx context.Context) {
+		cntr := Successful(ec.Inspect(ctx, nervousNspawn.Name))
+		Expect(cntr).To(And(
+			HaveID(nervousNspawn.Name),
+			HaveName(nervousNspawn.Name),
+			HaveProject(""),
+		))
+		Expect(cntr.PID).To(Equal(int(nervousNspawn.Leader)))
+		Expect(cntr.Labels).To(Equal(map[string]string{
+			ClassLabel:   ContainerClass,
+			ServiceLabel: nervousNspawn.Service,
+			UnitLabel:    nervousNspawn.Unit,
+		}))
+	})
+
+	It("inspects using a typed rucksack packer", func(ctx context.Context) {
+		WithTypedRucksackPacker(engineclient.RucksackPackerFunc[*Machine](
+			func(container *whalewatcher.Container, inspection *Machine) {
+				container.Rucksack = inspection.RootDirectory
+			}))(ec)
+		cntr := Successful(ec.Inspect(ctx, nervousNspawn.Name))
+		Expect(whalewatcher.RucksackOf[string](cntr)).To(Equal(nervousNspawn.RootDirectory))
+	})
+
+	It("lists containers", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		mm.AddMachine(virtualVictim)
+		mm.AddMachine(mockingmachined.MockedMachine{Name: "leaderless"})
+		Expect(ec.List(ctx)).To(ConsistOf(HaveID(nervousNspawn.Name)))
+
+		cancel()
+		Expect(ec.List(ctx)).Error().To(HaveOccurred())
+	})
+
+	It("watches containers come and go", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Expect(evs).NotTo(BeNil())
+		Expect(errs).NotTo(BeNil())
+		// The match rule gets added asynchronously, so keep registering new
+		// machines until we see the first signal.
+		Eventually(func() <-chan engineclient.ContainerEvent {
+			mm.RemoveMachine("spam")
+			mm.AddMachine(mockingmachined.MockedMachine{Name: "spam", Leader: 42})
+			return evs
+		}).Should(Receive(HaveID("spam")))
+		mm.RemoveMachine("spam")
+		Eventually(evs).Should(Receive(And(
+			HaveID("spam"),
+			HaveEventType(engineclient.ContainerExited),
+		)))
+		Consistently(errs).ShouldNot(Receive())
+
+		By("adding a new container")
+		mm.AddMachine(mockingmachined.MockedMachine{Name: "new-nspawn", Leader: 4242})
+		Eventually(evs).Should(Receive(And(
+			HaveTimestamp(Not(BeZero())),
+			HaveID("new-nspawn"),
+			HaveEventType(engineclient.ContainerStarted),
+			HaveProject(""),
+		)))
+
+		By("removing the container")
+		mm.RemoveMachine("new-nspawn")
+		Eventually(evs).Should(Receive(And(
+			HaveID("new-nspawn"),
+			HaveEventType(engineclient.ContainerExited),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("reports the D-Bus connection going away", func(ctx context.Context) {
+		evs, errs := ec.LifecycleEvents(ctx)
+		// Only close the connection after the subscription has been
+		// established, that is, after we've seen the first signal.
+		Eventually(func() <-chan engineclient.ContainerEvent {
+			mm.RemoveMachine("spam")
+			mm.AddMachine(mockingmachined.MockedMachine{Name: "spam", Leader: 42})
+			return evs
+		}).Should(Receive(HaveID("spam")))
+		Consistently(errs).ShouldNot(Receive())
+		ec.Close()
+		Eventually(errs).Should(Receive(MatchError(ErrClosed)))
+		Eventually(errs).Should(BeClosed())
+	})
+
+	It("reports the D-Bus connection having gone away before subscribing", func(ctx context.Context) {
+		ec.Close()
+		_, errs := ec.LifecycleEvents(ctx)
+		Eventually(errs).Should(Receive(MatchError(ErrClosed)))
+		Eventually(errs).Should(BeClosed())
+	})
+
+})
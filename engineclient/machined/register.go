@@ -0,0 +1,56 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machined
+
+import (
+	"net/url"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for systemd-machined, such as
+// "machined:///run/dbus/system_bus_socket" with the path of the D-Bus message
+// bus socket. With an empty path, the system bus applies. The only query
+// parameter is "pid".
+const Scheme = "machined"
+
+func init() {
+	engineclient.Register(Scheme, open)
+}
+
+// open returns a new MachinedWatcher configured by the specified engine URL.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	sockpath, err := engineclient.SocketPath(u)
+	if err != nil {
+		return nil, err
+	}
+	var address string
+	if sockpath != "" {
+		address = "unix:path=" + sockpath
+	}
+	client, err := NewClient(address)
+	if err != nil {
+		return nil, err
+	}
+	return NewMachinedWatcher(client, WithPID(pid)), nil
+}
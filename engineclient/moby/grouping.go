@@ -0,0 +1,33 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package moby
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+)
+
+// StackNamespaceLabel is the name of the container label identifying the
+// Docker Swarm stack a container is part of.
+const StackNamespaceLabel = "com.docker.stack.namespace"
+
+// GroupByStack is a [whalewatcher.Grouping] that groups containers by their
+// Docker Swarm stacks. Containers not belonging to any stack end up in their
+// composer projects instead.
+func GroupByStack(cntr *whalewatcher.Container) string {
+	if stack, ok := cntr.Labels[StackNamespaceLabel]; ok {
+		return stack
+	}
+	return cntr.Project
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package moby
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("grouping", func() {
+
+	It("groups by stack", func() {
+		Expect(GroupByStack(&whalewatcher.Container{
+			Labels:  map[string]string{StackNamespaceLabel: "stackie"},
+			Project: "grumpy",
+		})).To(Equal("stackie"))
+		Expect(GroupByStack(&whalewatcher.Container{Project: "grumpy"})).To(Equal("grumpy"))
+	})
+
+})
@@ -17,9 +17,13 @@ package moby
 import (
 	"context"
 	"maps"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/client"
 
 	"github.com/thediveo/whalewatcher/v2"
@@ -98,13 +102,21 @@ func WithDemonType(pseudonym string) NewOption {
 // WithRucksackPacker sets the Rucksack packer that adds application-specific
 // container information based on the inspected container data. The specified
 // Rucksack packer gets passed the inspection data in form of a Docker client
-// types.ContainerJSON.
+// ContainerInspectResult. Please consider using [WithTypedRucksackPacker]
+// instead.
 func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
 	return func(mw *MobyWatcher) {
 		mw.packer = packer
 	}
 }
 
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[client.ContainerInspectResult]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
 // ID returns the (more or less) unique engine identifier; the exact format is
 // engine-specific.
 func (mw *MobyWatcher) ID(ctx context.Context) string {
@@ -196,6 +208,14 @@ func (mw *MobyWatcher) Inspect(ctx context.Context, nameorid string) (*whalewatc
 		Project: details.Container.Config.Labels[ComposerProjectLabel],
 		Paused:  details.Container.State.Paused,
 	}
+	// Docker reports the start time of never started containers as the zero
+	// time, so there's no need to special case it.
+	if startedAt, err := time.Parse(time.RFC3339Nano, details.Container.State.StartedAt); err == nil {
+		cntr.StartedAt = startedAt
+	}
+	if health := details.Container.State.Health; health != nil && health.Status != container.NoHealthcheck {
+		cntr.Health = string(health.Status)
+	}
 	if details.Container.HostConfig != nil && details.Container.HostConfig.Privileged {
 		// Just the presence of the "magic" label is sufficient; the label's
 		// value doesn't matter.
@@ -209,20 +229,60 @@ func (mw *MobyWatcher) Inspect(ctx context.Context, nameorid string) (*whalewatc
 	return cntr, nil
 }
 
+// eventTypes maps the Docker container event actions of interest to us to
+// their lifecycle event types.
+var eventTypes = map[events.Action]engineclient.ContainerEventType{
+	events.ActionStart:   engineclient.ContainerStarted,
+	events.ActionDie:     engineclient.ContainerExited,
+	events.ActionPause:   engineclient.ContainerPaused,
+	events.ActionUnPause: engineclient.ContainerUnpaused,
+	events.ActionCreate:  engineclient.ContainerCreated,
+	events.ActionDestroy: engineclient.ContainerRemoved,
+	events.ActionKill:    engineclient.ContainerKilled,
+	events.ActionOOM:     engineclient.ContainerOOMKilled,
+	events.ActionRestart: engineclient.ContainerRestarting,
+	events.ActionStop:    engineclient.ContainerStopped,
+}
+
+// healthStatuses maps the Docker health_status event actions to the health
+// statuses of containers. Please note that Docker additionally emits
+// "free-form" health_status events, which we ignore.
+var healthStatuses = map[events.Action]string{
+	events.ActionHealthStatus + ": starting": whalewatcher.HealthStarting,
+	events.ActionHealthStatusHealthy:         whalewatcher.HealthHealthy,
+	events.ActionHealthStatusUnhealthy:       whalewatcher.HealthUnhealthy,
+}
+
 // LifecycleEvents streams container engine events, limited just to those events
 // in the lifecycle of containers getting born (=alive, as opposed to, say,
-// "conceived") and die.
+// "conceived") and die, changing their health status, as well as informational
+// lifecycle events, such as containers getting created and removed.
 func (mw *MobyWatcher) LifecycleEvents(ctx context.Context) (<-chan engineclient.ContainerEvent, <-chan error) {
 	cntreventstream := make(chan engineclient.ContainerEvent)
 	cntrerrstream := make(chan error, 1)
 
 	go func() {
 		defer close(cntrerrstream)
+		actions := make([]string, 0, len(eventTypes))
+		for action := range eventTypes {
+			actions = append(actions, string(action))
+		}
+		// Filtering for "health_status" makes Docker match all event actions
+		// by prefix, so we get all the different health_status events.
+		actions = append(actions, string(events.ActionHealthStatus))
+		slices.Sort(actions)
 		evfilters := make(client.Filters).
 			Add("type", "container").
-			Add("event", "start", "stop", "die", "pause", "unpause")
+			Add("event", actions...)
 		res := mw.moby.Events(ctx, client.EventsListOptions{Filters: evfilters})
 		evs, errs := res.Messages, res.Err
+		// Docker emits separate "oom" events before the "die" events of
+		// OOM-killed containers. As the OOM killer might also have killed
+		// other container processes than the initial one, we prefer the
+		// OOM-killed state of an exited container as inspected when it died;
+		// see oomKilled for details. Otherwise, we fall back to remembering
+		// the OOM events since the container's last start.
+		oomkilled := map[string]struct{}{}
 		for {
 			select {
 			case err := <-errs:
@@ -236,39 +296,62 @@ func (mw *MobyWatcher) LifecycleEvents(ctx context.Context) (<-chan engineclient
 				cntrerrstream <- err
 				return
 			case ev := <-evs:
-				switch ev.Action {
-				case "start":
-					cntreventstream <- engineclient.ContainerEvent{
-						Timestamp: time.Unix(0, ev.TimeNano),
-						Type:      engineclient.ContainerStarted,
-						ID:        ev.Actor.ID,
-						Project:   ev.Actor.Attributes[ComposerProjectLabel],
-					}
-				case "die":
-					cntreventstream <- engineclient.ContainerEvent{
-						Timestamp: time.Unix(0, ev.TimeNano),
-						Type:      engineclient.ContainerExited,
-						ID:        ev.Actor.ID,
-						Project:   ev.Actor.Attributes[ComposerProjectLabel],
-					}
-				case "pause":
-					cntreventstream <- engineclient.ContainerEvent{
-						Timestamp: time.Unix(0, ev.TimeNano),
-						Type:      engineclient.ContainerPaused,
-						ID:        ev.Actor.ID,
-						Project:   ev.Actor.Attributes[ComposerProjectLabel],
-					}
-				case "unpause":
-					cntreventstream <- engineclient.ContainerEvent{
-						Timestamp: time.Unix(0, ev.TimeNano),
-						Type:      engineclient.ContainerUnpaused,
-						ID:        ev.Actor.ID,
-						Project:   ev.Actor.Attributes[ComposerProjectLabel],
+				evtype, ok := eventTypes[ev.Action]
+				health, healthy := healthStatuses[ev.Action]
+				switch {
+				case healthy:
+					evtype = engineclient.ContainerHealthChanged
+				case !ok:
+					continue
+				}
+				cntrev := engineclient.ContainerEvent{
+					Timestamp: time.Unix(0, ev.TimeNano),
+					Type:      evtype,
+					ID:        ev.Actor.ID,
+					Project:   ev.Actor.Attributes[ComposerProjectLabel],
+				}
+				switch evtype {
+				case engineclient.ContainerHealthChanged:
+					cntrev.Health = health
+				case engineclient.ContainerOOMKilled:
+					oomkilled[ev.Actor.ID] = struct{}{}
+				case engineclient.ContainerStarted, engineclient.ContainerRestarting,
+					engineclient.ContainerRemoved:
+					delete(oomkilled, ev.Actor.ID)
+				case engineclient.ContainerExited:
+					exitcode, _ := strconv.Atoi(ev.Actor.Attributes["exitCode"])
+					_, oom := oomkilled[ev.Actor.ID]
+					delete(oomkilled, ev.Actor.ID)
+					oom = mw.oomKilled(ctx, ev.Actor.ID, oom)
+					cntrev.Exit = &engineclient.ExitInfo{
+						ExitCode:  exitcode,
+						ExitedAt:  cntrev.Timestamp,
+						OOMKilled: oom,
 					}
 				}
+				cntreventstream <- cntrev
 			}
 		}
 	}()
 
 	return cntreventstream, cntrerrstream
 }
+
+// exitInspectionTimeout limits the time spent on inspecting a container that
+// just died, as inspecting holds up passing on any further events.
+const exitInspectionTimeout = 2 * time.Second
+
+// oomKilled returns the OOM-killed state of the container with the specified
+// ID that just died, as inspected. If the container cannot be inspected in
+// time or is already running again, oomKilled returns the specified fallback
+// state instead. Please note that containers run with "--rm" might already be
+// gone by the time of inspection, so the fallback state then applies.
+func (mw *MobyWatcher) oomKilled(ctx context.Context, id string, fallback bool) bool {
+	ctx, cancel := context.WithTimeout(ctx, exitInspectionTimeout)
+	defer cancel()
+	details, err := mw.moby.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+	if err != nil || details.Container.State == nil || details.Container.State.Running {
+		return fallback
+	}
+	return details.Container.State.OOMKilled
+}
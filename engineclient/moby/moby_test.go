@@ -16,6 +16,7 @@ package moby
 
 import (
 	"context"
+	"time"
 
 	"github.com/moby/moby/client"
 
@@ -27,6 +28,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gleak"
+	. "github.com/onsi/gomega/gstruct"
 	. "github.com/thediveo/fdooze"
 	. "github.com/thediveo/success"
 )
@@ -151,6 +153,16 @@ var _ = Describe("moby engineclient", func() {
 		Expect(ec.Inspect(ctx, furiousFuruncle.ID)).Error().To(HaveOccurred())
 	})
 
+	It("inspects a furuncle using a typed rucksack packer", func(ctx context.Context) {
+		defer func() { ec.packer = nil }()
+		WithTypedRucksackPacker(engineclient.RucksackPackerFunc[client.ContainerInspectResult](
+			func(container *whalewatcher.Container, inspection client.ContainerInspectResult) {
+				container.Rucksack = inspection.Container.ID
+			}))(ec)
+		cntr := Successful(ec.Inspect(ctx, furiousFuruncle.ID))
+		Expect(whalewatcher.RucksackOf[string](cntr)).To(Equal(furiousFuruncle.ID))
+	})
+
 	It("lists furuncle", func(ctx context.Context) {
 		ctx, cancel := context.WithCancel(ctx)
 
@@ -211,4 +223,144 @@ var _ = Describe("moby engineclient", func() {
 		Eventually(errs).Should(Receive(Equal(ctx.Err())))
 	})
 
+	It("reports exit information", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Consistently(evs).ShouldNot(Receive())
+
+		startedAt := time.Date(2026, 10, 16, 12, 34, 56, 0, time.UTC)
+		oomer := madMay
+		oomer.StartedAt = startedAt
+		oomer.ExitCode = 137
+		mm.AddContainer(oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerStarted)))
+		cntr := Successful(ec.Inspect(ctx, oomer.ID))
+		Expect(cntr.StartedAt).To(BeTemporally("==", startedAt))
+
+		By("getting OOM-killed and automatically removed")
+		// As the container is already gone when it dies, it cannot be
+		// inspected anymore, so the earlier OOM event must be used instead.
+		mm.EmitEvent("oom", oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerOOMKilled)))
+		mm.RemoveContainer(oomer.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(oomer.ID),
+			HaveEventType(engineclient.ContainerExited),
+			HaveField("Exit", PointTo(And(
+				HaveField("ExitCode", 137),
+				HaveField("ExitedAt", Not(BeZero())),
+				HaveField("OOMKilled", BeTrue()),
+			))),
+		)))
+
+		By("getting OOM-killed, restarted, and then gracefully stopped")
+		mm.AddContainer(oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerStarted)))
+		mm.EmitEvent("oom", oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerOOMKilled)))
+		mm.EmitEvent("restart", oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerRestarting)))
+		mm.RemoveContainer(oomer.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(oomer.ID),
+			HaveEventType(engineclient.ContainerExited),
+			HaveField("Exit.OOMKilled", BeFalse()),
+		)))
+
+		By("inspecting the OOM-killed state of a dead container")
+		oomer.OOMKilled = true
+		mm.AddContainer(oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerStarted)))
+		mm.StopContainer(oomer.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(oomer.ID),
+			HaveEventType(engineclient.ContainerExited),
+			HaveField("Exit.OOMKilled", BeTrue()),
+		)))
+		mm.RemoveContainer(oomer.ID)
+
+		By("dying a normal death")
+		mm.AddContainer(madMay)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerStarted)))
+		mm.RemoveContainer(madMay.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(madMay.ID),
+			HaveEventType(engineclient.ContainerExited),
+			HaveField("Exit", PointTo(And(
+				HaveField("ExitCode", 0),
+				HaveField("OOMKilled", BeFalse()),
+			))),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("tracks health status", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Consistently(evs).ShouldNot(Receive())
+
+		Expect(ec.Inspect(ctx, furiousFuruncle.ID)).To(HaveField("Health", BeEmpty()))
+
+		for _, health := range []string{
+			whalewatcher.HealthStarting,
+			whalewatcher.HealthHealthy,
+			whalewatcher.HealthUnhealthy,
+		} {
+			By("changing the health status to " + health)
+			mm.SetContainerHealth(furiousFuruncle.ID, health)
+			Eventually(evs).Should(Receive(And(
+				HaveID(furiousFuruncle.ID),
+				HaveEventType(engineclient.ContainerHealthChanged),
+				HaveProject(furiousFuruncle.Labels[ComposerProjectLabel]),
+				HaveField("Health", health),
+			)))
+			Expect(ec.Inspect(ctx, furiousFuruncle.ID)).To(HaveField("Health", health))
+		}
+
+		By("ignoring free-form health status events")
+		mm.EmitEvent("health_status: running", furiousFuruncle)
+		Consistently(evs).ShouldNot(Receive())
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("passes on informational lifecycle events", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Consistently(evs).ShouldNot(Receive())
+
+		for _, action := range []struct {
+			action string
+			evtype engineclient.ContainerEventType
+		}{
+			{"create", engineclient.ContainerCreated},
+			{"kill", engineclient.ContainerKilled},
+			{"oom", engineclient.ContainerOOMKilled},
+			{"restart", engineclient.ContainerRestarting},
+			{"stop", engineclient.ContainerStopped},
+			{"destroy", engineclient.ContainerRemoved},
+		} {
+			By("emitting a " + action.action + " event")
+			mm.EmitEvent(action.action, madMay)
+			Eventually(evs).Should(Receive(And(
+				HaveID(madMay.ID),
+				HaveEventType(action.evtype),
+				HaveProject(madMay.Labels[ComposerProjectLabel]),
+			)))
+		}
+
+		By("ignoring other events")
+		mm.EmitEvent("rename", madMay)
+		Consistently(evs).ShouldNot(Receive())
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
 })
@@ -0,0 +1,73 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package moby
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/moby/moby/client"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for Docker engines, such as
+// "docker:///run/docker.sock", "docker+unix:///run/docker.sock", or
+// "docker+tcp://localhost:2375". With an empty path, Docker's usual client
+// defaults apply. Query parameters are "pid" and "type" (see [WithDemonType]).
+const Scheme = "docker"
+
+func init() {
+	engineclient.Register(Scheme, open)
+}
+
+// open returns a new MobyWatcher configured by the specified engine URL.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid", "type")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	clientopts := []client.Opt{
+		client.FromEnv,
+	}
+	switch _, transport := engineclient.SplitScheme(u.Scheme); transport {
+	case "tcp":
+		if u.Host == "" {
+			return nil, fmt.Errorf("engine URL %q lacks host", u.Redacted())
+		}
+		clientopts = append(clientopts, client.WithHost("tcp://"+u.Host))
+	default:
+		sockpath, err := engineclient.SocketPath(u)
+		if err != nil {
+			return nil, err
+		}
+		if sockpath != "" {
+			clientopts = append(clientopts, client.WithHost("unix://"+sockpath))
+		}
+	}
+	moby, err := client.New(clientopts...)
+	if err != nil {
+		return nil, err
+	}
+	opts := []NewOption{WithPID(pid)}
+	if query.Has("type") {
+		opts = append(opts, WithDemonType(query.Get("type")))
+	}
+	return NewMobyWatcher(moby, opts...), nil
+}
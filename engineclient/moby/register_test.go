@@ -0,0 +1,47 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package moby
+
+import (
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Docker engine URLs", func() {
+
+	It("opens engine URLs", func() {
+		ec := Successful(engineclient.Open("docker:///run/foo.sock"))
+		defer ec.Close()
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.API()).To(Equal("unix:///run/foo.sock"))
+
+		ec = Successful(engineclient.Open("docker+tcp://localhost:2375?pid=42&type=podman.io"))
+		defer ec.Close()
+		Expect(ec.Type()).To(Equal("podman.io"))
+		Expect(ec.PID()).To(Equal(42))
+		Expect(ec.API()).To(Equal("tcp://localhost:2375"))
+	})
+
+	It("rejects invalid engine URLs", func() {
+		Expect(engineclient.Open("docker+tcp:///run/docker.sock")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("docker+unix://localhost/run/docker.sock")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("docker:///run/docker.sock?ignore=moby")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("docker:///run/docker.sock?pid=-1")).Error().To(HaveOccurred())
+	})
+
+})
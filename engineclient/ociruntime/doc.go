@@ -0,0 +1,32 @@
+/*
+Package ociruntime implements an "engineless" EngineClient for containers
+started directly using OCI runtimes, such as runc and crun, without any
+container engine. It discovers containers from the state directories of OCI
+runtimes: each container has its own directory inside a state root directory,
+named after the container ID and containing a state file.
+
+The state files of different OCI runtimes come in different flavors:
+  - the standard OCI state in "state.json", with "id", "status", "pid",
+    "bundle", and "annotations".
+  - runc's state in "state.json", with "id", "init_process_pid", and
+    "config.labels" in "key=value" format, but without any "status".
+  - crun's state in "status", with "pid" and "bundle", but without any "status".
+
+As runc and crun state files lack a status, the status is then derived in the
+same way as runc and crun do: a container is stopped unless its initial process
+still exists and was started at the time recorded in the state file, so that
+reused PIDs don't count. It is created as long as its [ExecFifo] exists, and
+paused when its cgroup is frozen (cgroup v2 "cgroup.freeze" or cgroup v1
+"freezer.state").
+
+Container lifecycle events are derived from inotify events for the state roots
+and the individual container state directories. As exiting container
+processes as well as pausing and unpausing containers don't change the state
+files, the states of known containers are additionally polled. Please note
+that state roots that don't exist when starting to watch are ignored.
+
+Containers are identified by their IDs, which also are their names. The
+annotations (or runc labels) of a container become the container labels, with
+the optional [ComposerProjectLabel] determining the composer project.
+*/
+package ociruntime
@@ -0,0 +1,313 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// ErrOverflow is returned when the inotify event queue has overflown, so that
+// lifecycle events have been lost.
+var ErrOverflow = errors.New("inotify event queue overflow")
+
+// The inotify events of interest for state root directories and container
+// state directories.
+const (
+	rootEvents      = unix.IN_CREATE | unix.IN_MOVED_TO | unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_ONLYDIR
+	containerEvents = unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO | unix.IN_DELETE | unix.IN_ONLYDIR
+)
+
+// inotifyEvent is a single inotify event read from an inotify instance.
+type inotifyEvent struct {
+	wd   int
+	mask uint32
+	name string
+}
+
+// known is the last known state of a container while streaming events.
+type known struct {
+	root    string
+	status  string
+	project string
+}
+
+// eventstream tracks the state roots and container state directories watched
+// using a particular inotify instance.
+type eventstream struct {
+	ctx        context.Context
+	fd         int               // inotify file descriptor; never use os.File.Fd() as it switches to blocking mode.
+	roots      map[int]string    // watch descriptors of state roots.
+	dirs       map[int]string    // watch descriptors of container state directories, mapping to container IDs.
+	containers map[string]*known // last known container states, by container ID.
+	evs        chan<- engineclient.ContainerEvent
+}
+
+// LifecycleEvents streams container engine events, limited just to those events
+// in the lifecycle of containers getting born (=alive, as opposed to, say,
+// "conceived") and die. As there's no engine emitting events, the events are
+// derived from changes to the state directories and timestamped upon
+// detection. As runc and crun neither update their state files when container
+// processes exit nor when containers get paused, the states of the known
+// containers are additionally polled; see also [WithPollInterval].
+func (ow *OCIRuntimeWatcher) LifecycleEvents(ctx context.Context) (<-chan engineclient.ContainerEvent, <-chan error) {
+	cntreventstream := make(chan engineclient.ContainerEvent)
+	cntrerrstream := make(chan error, 1)
+
+	go func() {
+		defer close(cntrerrstream)
+		err := ow.stream(ctx, cntreventstream)
+		// Let a cancelled context take priority over any other event stream
+		// error, as the latter is usually just a consequence.
+		if ctx.Err() == context.Canceled {
+			err = ctx.Err()
+		}
+		cntrerrstream <- err
+	}()
+
+	return cntreventstream, cntrerrstream
+}
+
+// stream container lifecycle events derived from inotify events until the
+// context gets cancelled or an error occurs.
+func (ow *OCIRuntimeWatcher) stream(ctx context.Context, evs chan<- engineclient.ContainerEvent) error {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return os.NewSyscallError("inotify_init1", err)
+	}
+	// As the inotify file descriptor is non-blocking, reading from it gets
+	// handled by Go's runtime poller, so closing the file unblocks any
+	// pending read.
+	inotify := os.NewFile(uintptr(fd), "inotify")
+	defer func() { _ = inotify.Close() }()
+	stop := context.AfterFunc(ctx, func() { _ = inotify.Close() })
+	defer stop()
+
+	es := &eventstream{
+		ctx:        ctx,
+		fd:         fd,
+		roots:      map[int]string{},
+		dirs:       map[int]string{},
+		containers: map[string]*known{},
+		evs:        evs,
+	}
+	for _, root := range ow.roots {
+		wd, err := unix.InotifyAddWatch(fd, root, rootEvents)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return &fs.PathError{Op: "inotify_add_watch", Path: root, Err: err}
+		}
+		es.roots[wd] = root
+		entries, _ := os.ReadDir(root)
+		for _, entry := range entries {
+			if entry.IsDir() {
+				es.add(root, entry.Name(), false)
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	inotifyevs, inotifyerrs := read(inotify, done)
+	ticker := time.NewTicker(ow.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case evs := <-inotifyevs:
+			for _, ev := range evs {
+				if ev.mask&unix.IN_Q_OVERFLOW != 0 {
+					return ErrOverflow
+				}
+				if err := es.handle(ev.wd, ev.mask, ev.name); err != nil {
+					return err
+				}
+			}
+		case err := <-inotifyerrs:
+			if ctxerr := ctx.Err(); ctxerr != nil {
+				return ctxerr
+			}
+			return err
+		case <-ticker.C:
+			if err := es.poll(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// read inotify events from the specified inotify file in a separate go
+// routine, passing on the events read in batches, until reading fails or done
+// gets closed.
+func read(inotify *os.File, done <-chan struct{}) (<-chan []inotifyEvent, <-chan error) {
+	evs := make(chan []inotifyEvent)
+	errs := make(chan error, 1)
+	go func() {
+		buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+		for {
+			n, err := inotify.Read(buf)
+			if err != nil {
+				errs <- err
+				return
+			}
+			var batch []inotifyEvent
+			for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+				ev := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+				namelen := int(ev.Len)
+				name := strings.TrimRight(
+					string(buf[offset+unix.SizeofInotifyEvent:offset+unix.SizeofInotifyEvent+namelen]), "\x00")
+				offset += unix.SizeofInotifyEvent + namelen
+				batch = append(batch, inotifyEvent{wd: int(ev.Wd), mask: ev.Mask, name: name})
+			}
+			select {
+			case evs <- batch:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return evs, errs
+}
+
+// handle a single inotify event.
+func (es *eventstream) handle(wd int, mask uint32, name string) error {
+	if root, ok := es.roots[wd]; ok {
+		if mask&unix.IN_ISDIR == 0 {
+			return nil
+		}
+		switch {
+		case mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+			return es.add(root, name, true)
+		case mask&(unix.IN_DELETE|unix.IN_MOVED_FROM) != 0:
+			return es.remove(name)
+		}
+		return nil
+	}
+	id, ok := es.dirs[wd]
+	if !ok {
+		return nil
+	}
+	if mask&unix.IN_IGNORED != 0 {
+		delete(es.dirs, wd)
+		return nil
+	}
+	if slices.Contains(StateFiles, name) || name == ExecFifo {
+		return es.update(id)
+	}
+	return nil
+}
+
+// poll the states of all known containers, emitting lifecycle events in case
+// of relevant state changes that weren't signalled by changes to the state
+// directories, such as exited processes and frozen cgroups.
+func (es *eventstream) poll() error {
+	for id := range es.containers {
+		if err := es.update(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add a container state directory to the watched directories and learn about
+// the container's current state, optionally emitting a started event.
+func (es *eventstream) add(root, id string, emit bool) error {
+	wd, err := unix.InotifyAddWatch(es.fd, filepath.Join(root, id), containerEvents)
+	if err != nil {
+		// the container state directory might already be gone again.
+		return nil
+	}
+	es.dirs[wd] = id
+	es.containers[id] = &known{root: root, status: StatusStopped}
+	if !emit {
+		if state, err := ReadState(root, id); err == nil {
+			es.containers[id].status = state.Status
+			es.containers[id].project = state.Labels()[ComposerProjectLabel]
+		}
+		return nil
+	}
+	return es.update(id)
+}
+
+// remove a container whose state directory has gone, emitting an exited event
+// if the container was alive.
+func (es *eventstream) remove(id string) error {
+	k, ok := es.containers[id]
+	if !ok {
+		return nil
+	}
+	delete(es.containers, id)
+	if k.status == StatusRunning || k.status == StatusPaused {
+		return es.emit(engineclient.ContainerExited, id, k.project)
+	}
+	return nil
+}
+
+// update the known state of a container, emitting lifecycle events in case
+// of relevant state changes.
+func (es *eventstream) update(id string) error {
+	k, ok := es.containers[id]
+	if !ok {
+		return nil
+	}
+	state, err := ReadState(k.root, id)
+	if err != nil {
+		// state files might be incomplete or not yet written, so we simply
+		// wait for the next change.
+		return nil
+	}
+	oldstatus := k.status
+	k.status = state.Status
+	wasalive := oldstatus == StatusRunning || oldstatus == StatusPaused
+	switch {
+	case !wasalive && state.Alive():
+		k.project = state.Labels()[ComposerProjectLabel]
+		return es.emit(engineclient.ContainerStarted, id, k.project)
+	case wasalive && !state.Alive():
+		return es.emit(engineclient.ContainerExited, id, k.project)
+	case oldstatus == StatusRunning && state.Status == StatusPaused:
+		return es.emit(engineclient.ContainerPaused, id, k.project)
+	case oldstatus == StatusPaused && state.Status == StatusRunning:
+		return es.emit(engineclient.ContainerUnpaused, id, k.project)
+	}
+	return nil
+}
+
+// emit a container lifecycle event, unless the context has been cancelled.
+func (es *eventstream) emit(evtype engineclient.ContainerEventType, id, project string) error {
+	select {
+	case es.evs <- engineclient.ContainerEvent{
+		Type:      evtype,
+		ID:        id,
+		Project:   project,
+		Timestamp: time.Now(),
+	}:
+		return nil
+	case <-es.ctx.Done():
+		return es.ctx.Err()
+	}
+}
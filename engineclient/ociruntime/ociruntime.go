@@ -0,0 +1,226 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Type specifies this container engine's type identifier.
+const Type = "opencontainers.org/runtime"
+
+// ComposerProjectLabel is the name of an optional container annotation (or
+// runc label) identifying the composer project a container is part of.
+const ComposerProjectLabel = "com.docker.compose.project"
+
+// OCIRuntimeWatcher is an "engineless" EngineClient for interfacing the
+// generic whale watching with the state directories of OCI runtimes.
+type OCIRuntimeWatcher struct {
+	pid          int                         // optional engine PID when known.
+	roots        []string                    // state root directories.
+	pollInterval time.Duration               // interval for polling the states of known containers.
+	packer       engineclient.RucksackPacker // optional Rucksack packer for app-specific container information.
+}
+
+// DefaultPollInterval is the default interval for polling the states of known
+// containers while streaming lifecycle events.
+const DefaultPollInterval = time.Second
+
+// Make sure that the EngineClient interface is fully implemented.
+var _ (engineclient.EngineClient) = (*OCIRuntimeWatcher)(nil)
+
+// NewOCIRuntimeWatcher returns a new OCIRuntimeWatcher watching the state root
+// directories specified using [WithRoots], defaulting to [DefaultRoots].
+// Typically, you would want to use this lower-level constructor only in unit
+// tests and instead use watcher.ociruntime.New instead in most use cases.
+func NewOCIRuntimeWatcher(opts ...NewOption) *OCIRuntimeWatcher {
+	ow := &OCIRuntimeWatcher{
+		roots:        DefaultRoots,
+		pollInterval: DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(ow)
+	}
+	return ow
+}
+
+// NewOption represents options to NewOCIRuntimeWatcher when creating new
+// watchers keeping eyes on OCI runtime state directories.
+type NewOption func(*OCIRuntimeWatcher)
+
+// WithPID sets the engine's PID when known. As there is no engine, this is
+// probably only useful in unit tests.
+func WithPID(pid int) NewOption {
+	return func(ow *OCIRuntimeWatcher) {
+		ow.pid = pid
+	}
+}
+
+// WithRoots sets the state root directories to watch, such as
+// "/run/user/1000/runc" for rootless runc containers.
+func WithRoots(roots ...string) NewOption {
+	return func(ow *OCIRuntimeWatcher) {
+		ow.roots = slices.Clone(roots)
+	}
+}
+
+// WithPollInterval sets the interval for polling the states of known containers
+// while streaming lifecycle events, in order to detect exited container
+// processes as well as paused and unpaused containers. Non-positive intervals
+// are ignored, keeping the [DefaultPollInterval].
+func WithPollInterval(interval time.Duration) NewOption {
+	return func(ow *OCIRuntimeWatcher) {
+		if interval > 0 {
+			ow.pollInterval = interval
+		}
+	}
+}
+
+// WithRucksackPacker sets the Rucksack packer that adds application-specific
+// container information based on the inspected container data. The specified
+// Rucksack packer gets passed the inspection data in form of a *State. Please
+// consider using [WithTypedRucksackPacker] instead.
+func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
+	return func(ow *OCIRuntimeWatcher) {
+		ow.packer = packer
+	}
+}
+
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[*State]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
+// ID returns the (more or less) unique engine identifier, which is the host
+// name followed by the state root directories.
+func (ow *OCIRuntimeWatcher) ID(ctx context.Context) string {
+	hostname, _ := os.Hostname()
+	return hostname + ":" + strings.Join(ow.roots, ",")
+}
+
+// Type returns the type identifier for this container engine.
+func (ow *OCIRuntimeWatcher) Type() string { return Type }
+
+// Version information about the engine; as there is no engine, there is no
+// version either.
+func (ow *OCIRuntimeWatcher) Version(ctx context.Context) string { return "" }
+
+// API returns the container engine API path, that is, the state root
+// directories separated by colons.
+func (ow *OCIRuntimeWatcher) API() string { return strings.Join(ow.roots, ":") }
+
+// PID returns the container engine PID, when known.
+func (ow *OCIRuntimeWatcher) PID() int { return ow.pid }
+
+// Client returns the underlying engine client (engine-specific), which is the
+// list of state root directories.
+func (ow *OCIRuntimeWatcher) Client() any { return slices.Clone(ow.roots) }
+
+// Close cleans up and release any engine client resources, if necessary.
+func (ow *OCIRuntimeWatcher) Close() {}
+
+// IsNotFound returns true if the specified error reports a non-existing
+// container.
+func IsNotFound(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// List all the currently alive and kicking containers, but do not list any
+// containers without any processes.
+func (ow *OCIRuntimeWatcher) List(ctx context.Context) ([]*whalewatcher.Container, error) {
+	alives := []*whalewatcher.Container{}
+	for _, root := range ow.roots {
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		for _, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if !entry.IsDir() {
+				continue
+			}
+			state, err := ReadState(root, entry.Name())
+			if err != nil {
+				// silently ignore containers without (valid) state files, as
+				// they might have gone since reading the directory or might
+				// still be in the making.
+				continue
+			}
+			alive, err := ow.container(state)
+			if err != nil {
+				continue
+			}
+			alives = append(alives, alive)
+		}
+	}
+	return alives, nil
+}
+
+// Inspect (only) those container details of interest to us, given the ID of a
+// container; the state root directories are searched in order. If inspection
+// fails, it returns an error instead.
+func (ow *OCIRuntimeWatcher) Inspect(ctx context.Context, nameorid string) (*whalewatcher.Container, error) {
+	var err error = &fs.PathError{Op: "inspect", Path: nameorid, Err: fs.ErrNotExist}
+	for _, root := range ow.roots {
+		var state *State
+		state, err = ReadState(root, nameorid)
+		if err == nil {
+			return ow.container(state)
+		}
+		if !IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// container returns the container details for the specified state, or an error
+// if the container has no process.
+func (ow *OCIRuntimeWatcher) container(state *State) (*whalewatcher.Container, error) {
+	if !state.Alive() || state.PID() == 0 {
+		return nil, engineclient.NewProcesslessContainerError(state.ID, "OCI runtime")
+	}
+	labels := state.Labels()
+	cntr := &whalewatcher.Container{
+		ID:      state.ID,
+		Name:    state.ID,
+		Labels:  labels,
+		PID:     state.PID(),
+		Project: labels[ComposerProjectLabel],
+		Paused:  state.Status == StatusPaused,
+	}
+	// If someone wants to keep more details, let them pack it into the Rucksack
+	// of the container description.
+	if ow.packer != nil {
+		ow.packer.Pack(cntr, state)
+	}
+	return cntr, nil
+}
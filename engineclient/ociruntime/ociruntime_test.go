@@ -0,0 +1,259 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+	. "github.com/thediveo/whalewatcher/v2/test/matcher"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+// ociState returns a standard OCI state with the specified status and PID.
+func ociState(id, status string, pid int, annotations map[string]string) map[string]any {
+	return map[string]any{
+		"id":          id,
+		"status":      status,
+		"pid":         pid,
+		"annotations": annotations,
+	}
+}
+
+var _ = Describe("OCI runtime engineclient", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	var runcroot, crunroot string
+	var ec *OCIRuntimeWatcher
+
+	BeforeEach(func() {
+		runcroot = GinkgoT().TempDir()
+		crunroot = GinkgoT().TempDir()
+		ec = NewOCIRuntimeWatcher(WithRoots(runcroot, crunroot, "/nowhere"), WithPID(123456))
+		DeferCleanup(ec.Close)
+		writeState(runcroot, "furious_furuncle", "state.json", ociState(
+			"furious_furuncle", StatusRunning, 666,
+			map[string]string{ComposerProjectLabel: "testproject"}))
+	})
+
+	It("defaults to the runc and crun state roots", func() {
+		ec := NewOCIRuntimeWatcher()
+		Expect(ec.Client()).To(Equal(DefaultRoots))
+	})
+
+	It("has engine type ID, API path, client, and PID", func(ctx context.Context) {
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.API()).To(Equal(runcroot + ":" + crunroot + ":/nowhere"))
+		Expect(ec.Client()).To(Equal([]string{runcroot, crunroot, "/nowhere"}))
+		Expect(ec.PID()).To(Equal(123456))
+		Expect(ec.ID(ctx)).To(HaveSuffix(":" + runcroot + "," + crunroot + ",/nowhere"))
+		Expect(ec.Version(ctx)).To(BeEmpty())
+	})
+
+	It("inspects a furuncle", func(ctx context.Context) {
+		cntr := Successful(ec.Inspect(ctx, "furious_furuncle"))
+		Expect(cntr).To(And(
+			HaveID("furious_furuncle"),
+			HaveName("furious_furuncle"),
+			HaveProject("testproject"),
+		))
+		Expect(cntr.PID).To(Equal(666))
+		Expect(cntr.Paused).To(BeFalse())
+	})
+
+	It("cannot inspect missing or dead containers", func(ctx context.Context) {
+		Expect(ec.Inspect(ctx, "foobar")).Error().To(Satisfy(IsNotFound))
+		writeState(crunroot, "dead_dummy", "state.json", ociState("dead_dummy", StatusStopped, 0, nil))
+		Expect(ec.Inspect(ctx, "dead_dummy")).Error().To(Satisfy(engineclient.IsProcesslessContainer))
+		Expect(os.WriteFile(filepath.Join(runcroot, "furious_furuncle", "state.json"), []byte("{"), 0o600)).
+			To(Succeed())
+		Expect(ec.Inspect(ctx, "furious_furuncle")).Error().To(And(
+			HaveOccurred(), Not(Satisfy(IsNotFound))))
+	})
+
+	It("inspects using a typed rucksack packer", func(ctx context.Context) {
+		WithTypedRucksackPacker(engineclient.RucksackPackerFunc[*State](
+			func(container *whalewatcher.Container, inspection *State) {
+				container.Rucksack = inspection.Root
+			}))(ec)
+		cntr := Successful(ec.Inspect(ctx, "furious_furuncle"))
+		Expect(whalewatcher.RucksackOf[string](cntr)).To(Equal(runcroot))
+	})
+
+	It("lists containers", func(ctx context.Context) {
+		writeState(crunroot, "mad_mary", "status", map[string]any{"pid": os.Getpid()})
+		writeState(crunroot, "paused_paula", "state.json", ociState("paused_paula", StatusPaused, 42, nil))
+		writeState(crunroot, "dead_dummy", "state.json", ociState("dead_dummy", StatusStopped, 0, nil))
+		Expect(os.MkdirAll(filepath.Join(crunroot, "in_the_making"), 0o700)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(crunroot, "stray"), nil, 0o600)).To(Succeed())
+
+		Expect(ec.List(ctx)).To(ConsistOf(
+			And(HaveID("furious_furuncle"), HaveProject("testproject")),
+			And(HaveID("mad_mary"), HaveProject("")),
+			And(HaveID("paused_paula"), HaveField("Paused", BeTrue())),
+		))
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(ec.List(ctx)).Error().To(HaveOccurred())
+	})
+
+	It("watches containers come and go", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Expect(evs).NotTo(BeNil())
+		Expect(errs).NotTo(BeNil())
+		// give the event stream a chance to set up its watches.
+		time.Sleep(100 * time.Millisecond)
+		Consistently(evs).ShouldNot(Receive())
+
+		By("creating a new container")
+		writeState(crunroot, "mad_mary", "state.json", ociState("mad_mary", StatusCreated, 42,
+			map[string]string{ComposerProjectLabel: "otherproject"}))
+		Consistently(evs).ShouldNot(Receive())
+
+		By("starting the container")
+		writeState(crunroot, "mad_mary", "state.json", ociState("mad_mary", StatusRunning, 42,
+			map[string]string{ComposerProjectLabel: "otherproject"}))
+		Eventually(evs).Should(Receive(And(
+			HaveTimestamp(Not(BeZero())),
+			HaveID("mad_mary"),
+			HaveEventType(engineclient.ContainerStarted),
+			HaveProject("otherproject"),
+		)))
+
+		By("pausing the container")
+		writeState(crunroot, "mad_mary", "state.json", ociState("mad_mary", StatusPaused, 42, nil))
+		Eventually(evs).Should(Receive(And(
+			HaveID("mad_mary"),
+			HaveEventType(engineclient.ContainerPaused),
+			HaveProject("otherproject"),
+		)))
+
+		By("unpausing the container")
+		writeState(crunroot, "mad_mary", "state.json", ociState("mad_mary", StatusRunning, 42, nil))
+		Eventually(evs).Should(Receive(And(
+			HaveID("mad_mary"),
+			HaveEventType(engineclient.ContainerUnpaused),
+		)))
+
+		By("stopping the container")
+		writeState(crunroot, "mad_mary", "state.json", ociState("mad_mary", StatusStopped, 0, nil))
+		Eventually(evs).Should(Receive(And(
+			HaveID("mad_mary"),
+			HaveEventType(engineclient.ContainerExited),
+			HaveProject("otherproject"),
+		)))
+
+		By("deleting a stopped container")
+		Expect(os.RemoveAll(filepath.Join(crunroot, "mad_mary"))).To(Succeed())
+		Consistently(evs).ShouldNot(Receive())
+
+		By("deleting a running container")
+		Expect(os.RemoveAll(filepath.Join(runcroot, "furious_furuncle"))).To(Succeed())
+		Eventually(evs).Should(Receive(And(
+			HaveID("furious_furuncle"),
+			HaveEventType(engineclient.ContainerExited),
+			HaveProject("testproject"),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("notices runc containers starting, pausing, and exiting", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		sleepy := exec.Command("sleep", "60")
+		Expect(sleepy.Start()).To(Succeed())
+		DeferCleanup(func() {
+			_ = sleepy.Process.Kill()
+			_ = sleepy.Wait()
+		})
+		freeze := filepath.Join(GinkgoT().TempDir(), "cgroup.freeze")
+		writeFreeze(freeze, "0")
+
+		ec := NewOCIRuntimeWatcher(WithRoots(runcroot), WithPollInterval(50*time.Millisecond))
+		evs, errs := ec.LifecycleEvents(ctx)
+		// give the event stream a chance to set up its watches.
+		time.Sleep(100 * time.Millisecond)
+
+		By("creating a new container")
+		Expect(os.MkdirAll(filepath.Join(runcroot, "sleepy"), 0o700)).To(Succeed())
+		Expect(unix.Mkfifo(filepath.Join(runcroot, "sleepy", ExecFifo), 0o600)).To(Succeed())
+		writeRaw(runcroot, "sleepy", "state.json",
+			fmt.Sprintf(runcState, sleepy.Process.Pid, startTime(sleepy.Process.Pid), filepath.Dir(freeze)))
+		Consistently(evs).ShouldNot(Receive())
+
+		By("starting the container")
+		Expect(os.Remove(filepath.Join(runcroot, "sleepy", ExecFifo))).To(Succeed())
+		Eventually(evs).Should(Receive(And(
+			HaveID("sleepy"),
+			HaveEventType(engineclient.ContainerStarted),
+		)))
+
+		By("pausing the container")
+		writeFreeze(freeze, "1")
+		Eventually(evs).Should(Receive(And(
+			HaveID("sleepy"),
+			HaveEventType(engineclient.ContainerPaused),
+		)))
+
+		By("unpausing the container")
+		writeFreeze(freeze, "0")
+		Eventually(evs).Should(Receive(And(
+			HaveID("sleepy"),
+			HaveEventType(engineclient.ContainerUnpaused),
+		)))
+
+		By("terminating the container process")
+		Expect(sleepy.Process.Kill()).To(Succeed())
+		Eventually(evs).Should(Receive(And(
+			HaveID("sleepy"),
+			HaveEventType(engineclient.ContainerExited),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("reports inaccessible state roots", func(ctx context.Context) {
+		ec := NewOCIRuntimeWatcher(WithRoots(filepath.Join(runcroot, "furious_furuncle", "state.json")))
+		_, errs := ec.LifecycleEvents(ctx)
+		Eventually(errs).Should(Receive(HaveOccurred()))
+	})
+
+})
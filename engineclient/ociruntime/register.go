@@ -0,0 +1,58 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"net/url"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for OCI runtime state
+// directories, such as "ociruntime:///run/runc?root=/run/crun" with the path
+// and any "root" query parameters specifying the state root directories to
+// watch. Without any roots, [DefaultRoots] apply. The other query parameter is
+// "pid".
+const Scheme = "ociruntime"
+
+func init() {
+	engineclient.Register(Scheme, open)
+}
+
+// open returns a new OCIRuntimeWatcher configured by the specified engine URL.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid", "root")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	root, err := engineclient.SocketPath(u)
+	if err != nil {
+		return nil, err
+	}
+	var roots []string
+	if root != "" {
+		roots = append(roots, root)
+	}
+	roots = append(roots, engineclient.QueryList(query, "root")...)
+	opts := []NewOption{WithPID(pid)}
+	if len(roots) > 0 {
+		opts = append(opts, WithRoots(roots...))
+	}
+	return NewOCIRuntimeWatcher(opts...), nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("OCI runtime engine URLs", func() {
+
+	It("opens engine URLs", func() {
+		ec := Successful(engineclient.Open("ociruntime://"))
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.Client()).To(Equal(DefaultRoots))
+
+		ec = Successful(engineclient.Open("ociruntime:///run/runc?root=/run/crun,/run/youki&pid=42"))
+		Expect(ec.PID()).To(Equal(42))
+		Expect(ec.Client()).To(Equal([]string{"/run/runc", "/run/crun", "/run/youki"}))
+
+		ec = Successful(engineclient.Open("ociruntime://?root=/run/crun"))
+		Expect(ec.Client()).To(Equal([]string{"/run/crun"}))
+	})
+
+	It("rejects invalid engine URLs", func() {
+		Expect(engineclient.Open("ociruntime://localhost/run/runc")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("ociruntime:///run/runc?roots=/run/crun")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("ociruntime:///run/runc?pid=foo")).Error().To(HaveOccurred())
+	})
+
+})
@@ -0,0 +1,233 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// The OCI container states of interest to us.
+const (
+	StatusCreated = "created"
+	StatusRunning = "running"
+	StatusPaused  = "paused"
+	StatusStopped = "stopped"
+)
+
+// DefaultRoots are the default state root directories of runc and crun when
+// running as root.
+var DefaultRoots = []string{"/run/runc", "/run/crun"}
+
+// StateFiles are the names of the state files inside container state
+// directories.
+var StateFiles = []string{"state.json", "status"}
+
+// ExecFifo is the name of the FIFO inside container state directories that
+// runc and crun use to signal a created container to start. As long as it
+// exists, the container has been created but not yet started.
+const ExecFifo = "exec.fifo"
+
+// cgroupRoot is the mount point of the cgroup filesystem(s), which crun state
+// files refer to relatively.
+var cgroupRoot = "/sys/fs/cgroup"
+
+// procRoot is the mount point of the proc filesystem.
+var procRoot = "/proc"
+
+// State is the container state as read from a state file. It represents the
+// union of the standard OCI state as well as of the runc and crun state files.
+type State struct {
+	ID          string            `json:"id"`
+	Status      string            `json:"status"`
+	Pid         int               `json:"pid"`
+	Bundle      string            `json:"bundle"`
+	Annotations map[string]string `json:"annotations"`
+
+	InitProcessPid   int               `json:"init_process_pid"`   // runc only
+	InitProcessStart uint64            `json:"init_process_start"` // runc only, in clock ticks since boot.
+	CgroupPaths      map[string]string `json:"cgroup_paths"`       // runc only, absolute paths by controller.
+	Config           struct {
+		Labels []string `json:"labels"` // runc only, in "key=value" format.
+	} `json:"config"`
+
+	ProcessStartTime uint64 `json:"process-start-time"` // crun only, in clock ticks since boot.
+	CgroupPath       string `json:"cgroup-path"`        // crun only, relative to the cgroup root.
+
+	Root string          `json:"-"` // state root directory.
+	Path string          `json:"-"` // path of state file.
+	Raw  json.RawMessage `json:"-"` // raw state file contents.
+}
+
+// PID returns the PID of the container's initial process, or zero if unknown.
+func (s *State) PID() int {
+	if s.Pid != 0 {
+		return s.Pid
+	}
+	return s.InitProcessPid
+}
+
+// StartTime returns the start time of the container's initial process in
+// clock ticks since boot, or zero if unknown.
+func (s *State) StartTime() uint64 {
+	if s.InitProcessStart != 0 {
+		return s.InitProcessStart
+	}
+	return s.ProcessStartTime
+}
+
+// Labels returns the container's annotations (and runc labels) as labels,
+// with annotations taking precedence.
+func (s *State) Labels() map[string]string {
+	labels := map[string]string{}
+	for _, label := range s.Config.Labels {
+		key, value, _ := strings.Cut(label, "=")
+		labels[key] = value
+	}
+	for key, value := range s.Annotations {
+		labels[key] = value
+	}
+	return labels
+}
+
+// Alive returns true if the container has a process, that is, if it is either
+// running or paused.
+func (s *State) Alive() bool {
+	return s.Status == StatusRunning || s.Status == StatusPaused
+}
+
+// ReadState reads the state of the container with the specified ID from the
+// specified state root directory.
+func ReadState(root, id string) (*State, error) {
+	if id == "" || strings.ContainsRune(id, '/') || id == "." || id == ".." {
+		return nil, &fs.PathError{Op: "read", Path: id, Err: fs.ErrInvalid}
+	}
+	var err error
+	for _, name := range StateFiles {
+		var state *State
+		state, err = readStateFile(filepath.Join(root, id, name))
+		if err == nil {
+			state.Root = root
+			if state.ID == "" {
+				state.ID = id
+			}
+			return state, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// readStateFile reads the specified state file, deriving the status when the
+// state file lacks it.
+func readStateFile(path string) (*State, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &State{Path: path, Raw: raw}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, err
+	}
+	if state.Status == "" {
+		state.Status = state.derivedStatus(filepath.Dir(path))
+	}
+	return state, nil
+}
+
+// derivedStatus returns the container status derived in the same way as runc
+// and crun do, given the container's state directory: a container is stopped
+// unless its initial process still exists and hasn't been replaced by another
+// process reusing the PID. It is created as long as its exec FIFO exists, and
+// paused when its cgroup is frozen.
+func (s *State) derivedStatus(dir string) string {
+	if pid := s.PID(); pid <= 0 || !processAlive(pid, s.StartTime()) {
+		return StatusStopped
+	}
+	if _, err := os.Lstat(filepath.Join(dir, ExecFifo)); err == nil {
+		return StatusCreated
+	}
+	if s.frozen() {
+		return StatusPaused
+	}
+	return StatusRunning
+}
+
+// processAlive returns true if a process with the specified PID exists and
+// isn't a zombie. Unless starttime is zero, the process must additionally
+// have been started at the specified time (in clock ticks since boot), so that
+// another process later reusing the PID isn't mistaken for the original one.
+func processAlive(pid int, starttime uint64) bool {
+	stat, err := os.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return false
+	}
+	// The process name in parentheses might contain anything, including
+	// spaces and parentheses, so we skip everything up to the last closing
+	// parenthesis. The next field then is the process state (the third field),
+	// and the start time is the 22nd field.
+	idx := bytes.LastIndexByte(stat, ')')
+	if idx < 0 {
+		return false
+	}
+	fields := strings.Fields(string(stat[idx+1:]))
+	if len(fields) < 20 {
+		return false
+	}
+	if fields[0] == "Z" || fields[0] == "X" {
+		return false
+	}
+	if starttime == 0 {
+		return true
+	}
+	start, err := strconv.ParseUint(fields[19], 10, 64)
+	return err == nil && start == starttime
+}
+
+// frozen returns true if the container's cgroup is frozen, checking the
+// cgroup v2 freeze state as well as the cgroup v1 freezer state.
+func (s *State) frozen() bool {
+	var paths []string
+	if path, ok := s.CgroupPaths[""]; ok {
+		paths = append(paths, filepath.Join(path, "cgroup.freeze"))
+	}
+	if path, ok := s.CgroupPaths["freezer"]; ok {
+		paths = append(paths, filepath.Join(path, "freezer.state"))
+	}
+	if s.CgroupPath != "" {
+		paths = append(paths,
+			filepath.Join(cgroupRoot, s.CgroupPath, "cgroup.freeze"),
+			filepath.Join(cgroupRoot, "freezer", s.CgroupPath, "freezer.state"))
+	}
+	for _, path := range paths {
+		freeze, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		switch strings.TrimSpace(string(freeze)) {
+		case "1", "FROZEN":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+// runcState is a runc state.json as written by runc 1.1, with placeholders for
+// the initial process PID and start time, as well as the cgroup v2 path.
+const runcState = `{"id":"foo","init_process_pid":%d,"init_process_start":%d,"created":"2026-10-16T10:00:00.123456789Z","config":{"no_pivot_root":false,"parent_death_signal":0,"rootfs":"/bundles/foo/rootfs","umask":null,"readonlyfs":true,"rootPropagation":0,"mounts":[],"devices":[],"mount_label":"","hostname":"foo","namespaces":[{"type":"NEWPID","path":""}],"capabilities":null,"networks":null,"routes":null,"cgroups":{"path":"/default/foo","scope_prefix":"","Resources":{}},"oom_score_adj":0,"uid_mappings":null,"gid_mappings":null,"mask_paths":[],"readonly_paths":[],"sysctl":null,"seccomp":null,"Hooks":null,"version":"1.0.2-dev","labels":["bundle=/bundles/foo","foo=bar","baz"],"no_new_keyring":false},"rootless":false,"cgroup_paths":{"":%q},"namespace_paths":{"NEWPID":"/proc/1/ns/pid"},"external_descriptors":["/dev/null","pipe:[123]","pipe:[124]"],"intel_rdt_path":""}`
+
+// runcV1State is a runc state.json using cgroup v1, with placeholders for the
+// initial process PID and start time, as well as the freezer cgroup path.
+const runcV1State = `{"id":"foo","init_process_pid":%d,"init_process_start":%d,"created":"2026-10-16T10:00:00.123456789Z","config":{"rootfs":"/bundles/foo/rootfs","labels":["bundle=/bundles/foo"]},"rootless":false,"cgroup_paths":{"cpu":"/sys/fs/cgroup/cpu/default/foo","freezer":%q,"memory":"/sys/fs/cgroup/memory/default/foo"},"namespace_paths":{},"external_descriptors":["/dev/null","/dev/null","/dev/null"],"intel_rdt_path":""}`
+
+// crunStatus is a crun status file, with placeholders for the initial process
+// PID and start time, as well as the cgroup path relative to the cgroup root.
+const crunStatus = `{
+    "pid" : %d,
+    "process-start-time" : %d,
+    "cgroup-path" : %q,
+    "rootfs" : "/bundles/foo/rootfs",
+    "systemd-cgroup" : false,
+    "bundle" : "/bundles/foo",
+    "created" : "2026-10-16T10:00:00.123456789Z",
+    "owner" : "root",
+    "detached" : true,
+    "external_descriptors" : "[\"/dev/null\",\"pipe:[123]\",\"pipe:[124]\"]"
+}`
+
+// startTime returns the start time of the process with the specified PID, in
+// clock ticks since boot.
+func startTime(pid int) uint64 {
+	GinkgoHelper()
+	stat := string(Successful(os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))))
+	fields := strings.Fields(stat[strings.LastIndexByte(stat, ')')+1:])
+	return Successful(strconv.ParseUint(fields[19], 10, 64))
+}
+
+// writeRaw atomically writes the specified raw state file contents for the
+// container with the specified ID.
+func writeRaw(root, id, name, state string) {
+	GinkgoHelper()
+	writeState(root, id, name, json.RawMessage(state))
+}
+
+// writeFreeze writes the specified freeze state to the specified cgroup
+// control file, creating the cgroup directory as necessary.
+func writeFreeze(path, freeze string) {
+	GinkgoHelper()
+	Expect(os.MkdirAll(filepath.Dir(path), 0o700)).To(Succeed())
+	Expect(os.WriteFile(path, []byte(freeze+"\n"), 0o600)).To(Succeed())
+}
+
+// writeState atomically writes the specified state file for the container
+// with the specified ID, in the same way as runc does: writing to a temporary
+// file and then renaming it.
+func writeState(root, id, name string, state any) {
+	GinkgoHelper()
+	dir := filepath.Join(root, id)
+	Expect(os.MkdirAll(dir, 0o700)).To(Succeed())
+	tmp := filepath.Join(dir, "."+name+".tmp")
+	Expect(os.WriteFile(tmp, Successful(json.Marshal(state)), 0o600)).To(Succeed())
+	Expect(os.Rename(tmp, filepath.Join(dir, name))).To(Succeed())
+}
+
+var _ = Describe("OCI runtime state", func() {
+
+	var root string
+
+	BeforeEach(func() {
+		root = GinkgoT().TempDir()
+	})
+
+	It("reads standard OCI state", func() {
+		writeState(root, "foo", "state.json", map[string]any{
+			"id":          "foo",
+			"status":      "paused",
+			"pid":         42,
+			"bundle":      "/bundles/foo",
+			"annotations": map[string]string{"foo": "bar"},
+		})
+		state := Successful(ReadState(root, "foo"))
+		Expect(state.ID).To(Equal("foo"))
+		Expect(state.Root).To(Equal(root))
+		Expect(state.Path).To(Equal(filepath.Join(root, "foo", "state.json")))
+		Expect(state.Status).To(Equal(StatusPaused))
+		Expect(state.Alive()).To(BeTrue())
+		Expect(state.PID()).To(Equal(42))
+		Expect(state.Labels()).To(Equal(map[string]string{"foo": "bar"}))
+		Expect(string(state.Raw)).To(ContainSubstring(`"bundle":"/bundles/foo"`))
+	})
+
+	It("reads runc state", func() {
+		cgroup := filepath.Join(GinkgoT().TempDir(), "default", "foo")
+		writeFreeze(filepath.Join(cgroup, "cgroup.freeze"), "0")
+		writeRaw(root, "foo", "state.json",
+			fmt.Sprintf(runcState, os.Getpid(), startTime(os.Getpid()), cgroup))
+		state := Successful(ReadState(root, "foo"))
+		Expect(state.Status).To(Equal(StatusRunning))
+		Expect(state.PID()).To(Equal(os.Getpid()))
+		Expect(state.StartTime()).To(Equal(startTime(os.Getpid())))
+		Expect(state.Labels()).To(Equal(map[string]string{
+			"bundle": "/bundles/foo",
+			"foo":    "bar",
+			"baz":    "",
+		}))
+
+		By("detecting a frozen cgroup")
+		writeFreeze(filepath.Join(cgroup, "cgroup.freeze"), "1")
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusPaused))
+
+		By("detecting a not yet started container")
+		Expect(unix.Mkfifo(filepath.Join(root, "foo", ExecFifo), 0o600)).To(Succeed())
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusCreated))
+	})
+
+	It("reads runc state using cgroup v1", func() {
+		freezer := filepath.Join(GinkgoT().TempDir(), "freezer", "default", "foo")
+		writeFreeze(filepath.Join(freezer, "freezer.state"), "THAWED")
+		writeRaw(root, "foo", "state.json",
+			fmt.Sprintf(runcV1State, os.Getpid(), startTime(os.Getpid()), freezer))
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusRunning))
+		writeFreeze(filepath.Join(freezer, "freezer.state"), "FROZEN")
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusPaused))
+	})
+
+	It("reads crun state", func() {
+		oldCgroupRoot := cgroupRoot
+		DeferCleanup(func() { cgroupRoot = oldCgroupRoot })
+		cgroupRoot = GinkgoT().TempDir()
+
+		writeRaw(root, "foo", "status",
+			fmt.Sprintf(crunStatus, os.Getpid(), startTime(os.Getpid()), "/default/foo"))
+		state := Successful(ReadState(root, "foo"))
+		Expect(state.ID).To(Equal("foo"))
+		Expect(state.Status).To(Equal(StatusRunning))
+		Expect(state.Bundle).To(Equal("/bundles/foo"))
+		Expect(state.StartTime()).To(Equal(startTime(os.Getpid())))
+
+		By("detecting a frozen cgroup v2")
+		writeFreeze(filepath.Join(cgroupRoot, "default", "foo", "cgroup.freeze"), "1")
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusPaused))
+
+		By("detecting a frozen cgroup v1")
+		Expect(os.RemoveAll(filepath.Join(cgroupRoot, "default"))).To(Succeed())
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusRunning))
+		writeFreeze(filepath.Join(cgroupRoot, "freezer", "default", "foo", "freezer.state"), "FROZEN")
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusPaused))
+	})
+
+	It("doesn't mistake a process reusing a PID for the initial container process", func() {
+		writeRaw(root, "foo", "state.json",
+			fmt.Sprintf(runcState, os.Getpid(), startTime(os.Getpid())+1, "/nowhere"))
+		Expect(ReadState(root, "foo")).To(HaveField("Status", StatusStopped))
+		writeRaw(root, "bar", "status",
+			fmt.Sprintf(crunStatus, os.Getpid(), startTime(os.Getpid())-1, "/nowhere"))
+		Expect(ReadState(root, "bar")).To(HaveField("Status", StatusStopped))
+	})
+
+	It("considers containers without processes to be stopped", func() {
+		writeState(root, "foo", "status", map[string]any{"pid": 0})
+		state := Successful(ReadState(root, "foo"))
+		Expect(state.Status).To(Equal(StatusStopped))
+		Expect(state.Alive()).To(BeFalse())
+	})
+
+	It("rejects missing, invalid, and sneaky states", func() {
+		Expect(ReadState(root, "foo")).Error().To(Satisfy(IsNotFound))
+		Expect(os.MkdirAll(filepath.Join(root, "foo"), 0o700)).To(Succeed())
+		Expect(ReadState(root, "foo")).Error().To(Satisfy(IsNotFound))
+		Expect(os.WriteFile(filepath.Join(root, "foo", "state.json"), []byte("{"), 0o600)).To(Succeed())
+		Expect(ReadState(root, "foo")).Error().To(And(HaveOccurred(), Not(Satisfy(IsNotFound))))
+		for _, id := range []string{"", ".", "..", "../foo"} {
+			Expect(ReadState(root, id)).Error().To(HaveOccurred(), "id %q", id)
+		}
+	})
+
+})
@@ -0,0 +1,331 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// APIVersion is the libpod API version used by the Client; it is supported by
+// Podman 4 and later.
+const APIVersion = "v4.0.0"
+
+// RootfulSocket is the default path of the Podman API socket of the rootful
+// Podman service.
+const RootfulSocket = "/run/podman/podman.sock"
+
+// Client is a minimal client for Podman's native libpod REST API, supporting
+// only the API operations required for watching containers.
+type Client struct {
+	host   string       // API endpoint as passed in, or default.
+	base   string       // base URL of the libpod API, including version.
+	client *http.Client // HTTP client, possibly talking over a unix socket.
+}
+
+// DefaultHost returns the default Podman API endpoint: it is taken from the
+// CONTAINER_HOST environment variable if set. Otherwise, it is the rootful
+// API socket when running as root, or the rootless API socket of the current
+// user.
+func DefaultHost() string {
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		return host
+	}
+	if os.Geteuid() == 0 {
+		return "unix://" + RootfulSocket
+	}
+	return "unix://" + RootlessSocket()
+}
+
+// RootlessSocket returns the path of the current user's rootless Podman API
+// socket, located in the user's runtime directory.
+func RootlessSocket() string {
+	rundir := os.Getenv("XDG_RUNTIME_DIR")
+	if rundir == "" {
+		rundir = fmt.Sprintf("/run/user/%d", os.Geteuid())
+	}
+	return filepath.Join(rundir, "podman", "podman.sock")
+}
+
+// NewClient returns a new libpod API client for the specified API endpoint,
+// which is either a unix socket in "unix:///path" format (or just a plain
+// absolute path), or a TCP endpoint in "tcp://host:port" or "http://host:port"
+// format. If host is empty, [DefaultHost] is used instead. NewClient does not
+// contact the Podman service.
+func NewClient(host string) (*Client, error) {
+	if host == "" {
+		host = DefaultHost()
+	}
+	c := &Client{host: host}
+	switch {
+	case strings.HasPrefix(host, "unix://") || strings.HasPrefix(host, "/"):
+		sockpath := strings.TrimPrefix(host, "unix://")
+		if sockpath == "" {
+			return nil, fmt.Errorf("invalid Podman API endpoint %q", host)
+		}
+		var dialer net.Dialer
+		c.client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return dialer.DialContext(ctx, "unix", sockpath)
+				},
+			},
+		}
+		// The host part of the URL doesn't matter, as we always dial the unix
+		// socket, but it must be syntactically valid.
+		c.base = "http://d/" + APIVersion + "/libpod"
+	case strings.HasPrefix(host, "tcp://") || strings.HasPrefix(host, "http://"):
+		u, err := url.Parse(host)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid Podman API endpoint %q", host)
+		}
+		c.client = &http.Client{Transport: &http.Transport{}}
+		c.base = "http://" + u.Host + "/" + APIVersion + "/libpod"
+	default:
+		return nil, fmt.Errorf("unsupported Podman API endpoint %q", host)
+	}
+	return c, nil
+}
+
+// Host returns the API endpoint of this client.
+func (c *Client) Host() string { return c.host }
+
+// Close releases any idle connections to the Podman service.
+func (c *Client) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// APIError is an error response returned by the libpod API.
+type APIError struct {
+	StatusCode int    // HTTP status code.
+	Message    string // error message, if any.
+}
+
+// Error returns the error message.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Podman API error (status %d): %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound returns true if the specified error is an [APIError] reporting a
+// non-existing container or pod.
+func IsNotFound(err error) bool {
+	var apierr *APIError
+	return errors.As(err, &apierr) && apierr.StatusCode == http.StatusNotFound
+}
+
+// get the specified libpod API resource, returning the response body if
+// successful. The caller is responsible for closing the response body.
+func (c *Client) get(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	u := c.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		defer func() { _ = resp.Body.Close() }()
+		apierr := &APIError{StatusCode: resp.StatusCode}
+		var msg struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&msg) == nil {
+			apierr.Message = msg.Message
+		}
+		return nil, apierr
+	}
+	return resp.Body, nil
+}
+
+// getJSON gets the specified libpod API resource and decodes its JSON
+// representation into v.
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, v any) error {
+	body, err := c.get(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = body.Close() }()
+	return json.NewDecoder(body).Decode(v)
+}
+
+// Info is the subset of the Podman system information of interest to us.
+type Info struct {
+	Host struct {
+		Hostname string `json:"hostname"`
+	} `json:"host"`
+	Store struct {
+		GraphRoot string `json:"graphRoot"`
+	} `json:"store"`
+	Version struct {
+		APIVersion string `json:"APIVersion"`
+		Version    string `json:"Version"`
+	} `json:"version"`
+}
+
+// Info returns the Podman system information.
+func (c *Client) Info(ctx context.Context) (*Info, error) {
+	var info Info
+	if err := c.getJSON(ctx, "/info", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// ListedContainer is the subset of the information about a listed container of
+// interest to us.
+type ListedContainer struct {
+	ID string `json:"Id"`
+}
+
+// ContainerList returns the running and paused containers.
+func (c *Client) ContainerList(ctx context.Context) ([]ListedContainer, error) {
+	filters, _ := json.Marshal(map[string][]string{
+		"status": {"running", "paused"},
+	})
+	var cntrs []ListedContainer
+	if err := c.getJSON(ctx, "/containers/json", url.Values{
+		"all":     {"true"},
+		"filters": {string(filters)},
+	}, &cntrs); err != nil {
+		return nil, err
+	}
+	return cntrs, nil
+}
+
+// ContainerInspect is the subset of the container inspection information of
+// interest to us. Raw contains the complete inspection information in its
+// JSON representation.
+type ContainerInspect struct {
+	ID    string `json:"Id"`
+	Name  string `json:"Name"`
+	Pod   string `json:"Pod"` // pod ID, if any.
+	State struct {
+		Status    string    `json:"Status"`
+		Running   bool      `json:"Running"`
+		Paused    bool      `json:"Paused"`
+		Pid       int       `json:"Pid"`
+		StartedAt time.Time `json:"StartedAt"`
+	} `json:"State"`
+	Config struct {
+		Labels map[string]string `json:"Labels"`
+	} `json:"Config"`
+	HostConfig struct {
+		Privileged bool `json:"Privileged"`
+	} `json:"HostConfig"`
+	IsInfra bool `json:"IsInfra"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// ContainerInspect returns the inspection information of the container with
+// the specified name or ID.
+func (c *Client) ContainerInspect(ctx context.Context, nameorid string) (*ContainerInspect, error) {
+	var raw json.RawMessage
+	if err := c.getJSON(ctx, "/containers/"+url.PathEscape(nameorid)+"/json", nil, &raw); err != nil {
+		return nil, err
+	}
+	var details ContainerInspect
+	if err := json.Unmarshal(raw, &details); err != nil {
+		return nil, err
+	}
+	details.Raw = raw
+	return &details, nil
+}
+
+// PodInspect is the subset of the pod inspection information of interest to
+// us.
+type PodInspect struct {
+	ID   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+// PodInspect returns the inspection information of the pod with the specified
+// name or ID.
+func (c *Client) PodInspect(ctx context.Context, nameorid string) (*PodInspect, error) {
+	var pod PodInspect
+	if err := c.getJSON(ctx, "/pods/"+url.PathEscape(nameorid)+"/json", nil, &pod); err != nil {
+		return nil, err
+	}
+	return &pod, nil
+}
+
+// Event is the subset of a libpod event of interest to us.
+type Event struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	TimeNano int64 `json:"timeNano"`
+}
+
+// Events streams the container events of the specified types, such as "start"
+// and "died". The event channel is closed when the event stream ends, after
+// sending any error to the buffered error channel.
+func (c *Client) Events(ctx context.Context, actions ...string) (<-chan Event, <-chan error) {
+	evs := make(chan Event)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(evs)
+		filters, _ := json.Marshal(map[string][]string{
+			"type":  {"container"},
+			"event": actions,
+		})
+		body, err := c.get(ctx, "/events", url.Values{
+			"stream":  {"true"},
+			"filters": {string(filters)},
+		})
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer func() { _ = body.Close() }()
+		dec := json.NewDecoder(body)
+		for {
+			var ev Event
+			if err := dec.Decode(&ev); err != nil {
+				if err == io.EOF {
+					err = io.ErrUnexpectedEOF // event streams never end on their own.
+				}
+				errs <- err
+				return
+			}
+			select {
+			case evs <- ev:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return evs, errs
+}
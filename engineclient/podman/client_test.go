@@ -0,0 +1,154 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/thediveo/whalewatcher/v2/test/mockingpodman"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("libpod API client", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	Context("API endpoints", func() {
+
+		It("determines the default API endpoint", func() {
+			GinkgoT().Setenv("CONTAINER_HOST", "tcp://localhost:1234")
+			Expect(DefaultHost()).To(Equal("tcp://localhost:1234"))
+
+			GinkgoT().Setenv("CONTAINER_HOST", "")
+			GinkgoT().Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+			Expect(RootlessSocket()).To(Equal("/run/user/1000/podman/podman.sock"))
+			Expect(DefaultHost()).To(Or(
+				Equal("unix://"+RootfulSocket),
+				Equal("unix:///run/user/1000/podman/podman.sock")))
+		})
+
+		It("falls back to the user-specific runtime directory", func() {
+			GinkgoT().Setenv("XDG_RUNTIME_DIR", "")
+			Expect(RootlessSocket()).To(MatchRegexp(`^/run/user/\d+/podman/podman\.sock$`))
+		})
+
+		DescribeTable("accepts valid endpoints",
+			func(host string, base string) {
+				c := Successful(NewClient(host))
+				defer func() { _ = c.Close() }()
+				Expect(c.Host()).To(Equal(host))
+				Expect(c.base).To(Equal(base))
+			},
+			Entry("unix socket URL", "unix:///run/podman/podman.sock", "http://d/v4.0.0/libpod"),
+			Entry("plain unix socket path", "/run/podman/podman.sock", "http://d/v4.0.0/libpod"),
+			Entry("TCP", "tcp://localhost:8888", "http://localhost:8888/v4.0.0/libpod"),
+			Entry("HTTP", "http://localhost:8888", "http://localhost:8888/v4.0.0/libpod"),
+		)
+
+		DescribeTable("rejects invalid endpoints",
+			func(host string) {
+				Expect(NewClient(host)).Error().To(HaveOccurred())
+			},
+			Entry("empty unix socket path", "unix://"),
+			Entry("missing TCP host", "tcp://"),
+			Entry("unsupported scheme", "ssh://core@localhost:22"),
+			Entry("relative path", "podman.sock"),
+		)
+
+	})
+
+	Context("talking to a Podman service", func() {
+
+		var mp *mockingpodman.MockingPodman
+		var c *Client
+
+		BeforeEach(func() {
+			mp = Successful(mockingpodman.New())
+			DeferCleanup(mp.Close)
+			c = Successful(NewClient(mp.Host()))
+			DeferCleanup(c.Close)
+		})
+
+		It("gets the system information", func(ctx context.Context) {
+			info := Successful(c.Info(ctx))
+			Expect(info.Host.Hostname).To(Equal("mockinghost"))
+			Expect(info.Store.GraphRoot).NotTo(BeEmpty())
+			Expect(info.Version.Version).NotTo(BeEmpty())
+		})
+
+		It("reports API errors", func(ctx context.Context) {
+			_, err := c.ContainerInspect(ctx, "foobar")
+			Expect(err).To(HaveOccurred())
+			Expect(IsNotFound(err)).To(BeTrue())
+			var apierr *APIError
+			Expect(errors.As(err, &apierr)).To(BeTrue())
+			Expect(apierr.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(apierr.Error()).To(ContainSubstring("foobar"))
+
+			Expect(c.PodInspect(ctx, "foobar")).Error().To(Satisfy(IsNotFound))
+			Expect(IsNotFound(errors.New("D'OH!"))).To(BeFalse())
+		})
+
+		It("lists and inspects containers", func(ctx context.Context) {
+			mp.AddContainer(mockingpodman.MockedContainer{
+				ID: "1234", Name: "foo", PID: 42,
+				Labels: map[string]string{"foo": "bar"},
+			})
+			Expect(c.ContainerList(ctx)).To(ConsistOf(HaveField("ID", "1234")))
+			details := Successful(c.ContainerInspect(ctx, "foo"))
+			Expect(details.ID).To(Equal("1234"))
+			Expect(details.State.Pid).To(Equal(42))
+			Expect(details.Config.Labels).To(HaveKeyWithValue("foo", "bar"))
+			Expect(details.Raw).To(ContainSubstring(`"Id":"1234"`))
+		})
+
+		It("streams events until cancelled", func(ctx context.Context) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			evs, errs := c.Events(ctx, "start")
+			Eventually(mp.Subscribers).Should(Equal(1))
+			mp.AddContainer(mockingpodman.MockedContainer{ID: "1234", Name: "foo", PID: 42})
+			Eventually(evs).Should(Receive(And(
+				HaveField("Action", "start"),
+				HaveField("Actor.ID", "1234"))))
+			cancel()
+			Eventually(evs).Should(BeClosed())
+			Expect(<-errs).To(HaveOccurred())
+		})
+
+		It("reports ending event streams", func(ctx context.Context) {
+			evs, errs := c.Events(ctx, "start")
+			Eventually(mp.Subscribers).Should(Equal(1))
+			mp.Close()
+			Eventually(evs).Should(BeClosed())
+			Expect(<-errs).To(HaveOccurred())
+		})
+
+	})
+
+})
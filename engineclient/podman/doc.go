@@ -0,0 +1,17 @@
+/*
+Package podman implements the Podman EngineClient, using Podman's native libpod
+REST API instead of its Docker-compatible API. In contrast to the
+Docker-compatible API, the libpod API reveals which pods containers belong to.
+
+Containers that are part of a Podman pod belong to the composer project named
+after their pod, and are additionally labelled with [PodNameLabel] and
+[PodIDLabel]; pod infrastructure containers are marked with [InfraLabel].
+Containers not in any pod belong to the composer project specified by their
+[ComposerProjectLabel], if any.
+
+The Podman API endpoint defaults to the rootful Podman socket when running as
+root and to the current user's rootless Podman socket otherwise, unless the
+CONTAINER_HOST environment variable specifies a different endpoint; see
+[DefaultHost].
+*/
+package podman
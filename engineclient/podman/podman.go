@@ -0,0 +1,308 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Type specifies this container engine's type identifier.
+const Type = "podman.io"
+
+// ComposerProjectLabel is the name of an optional container label identifying
+// the composer project a container is part of; podman-compose sets this label
+// in the same way as Docker compose does.
+const ComposerProjectLabel = "com.docker.compose.project"
+
+// PodNameLabel is the name of the container label identifying the name of the
+// pod a container belongs to. It is set only for containers that are part of
+// a Podman pod.
+const PodNameLabel = "github.com/thediveo/whalewatcher/podman/pod-name"
+
+// PodIDLabel is the name of the container label identifying the ID of the pod
+// a container belongs to. It is set only for containers that are part of a
+// Podman pod.
+const PodIDLabel = "github.com/thediveo/whalewatcher/podman/pod-id"
+
+// InfraLabel is the name of a container label signalling by its sheer presence
+// that the labelled container is the infrastructure container of a pod. The
+// label's value is always empty.
+const InfraLabel = "github.com/thediveo/whalewatcher/podman/infra"
+
+// PrivilegedLabel is the name of a container label signalling by its sheer
+// presence that the labelled container has been started privileged. The
+// label's value is always empty.
+const PrivilegedLabel = "github.com/thediveo/whalewatcher/podman/privileged"
+
+// PodmanWatcher is a Podman EngineClient for interfacing the generic whale
+// watching with Podman services, using Podman's native libpod API.
+type PodmanWatcher struct {
+	pid    int                         // optional engine PID when known.
+	client *Client                     // libpod API client.
+	packer engineclient.RucksackPacker // optional Rucksack packer for app-specific container information.
+}
+
+// Make sure that the EngineClient interface is fully implemented.
+var _ (engineclient.EngineClient) = (*PodmanWatcher)(nil)
+
+// NewPodmanWatcher returns a new PodmanWatcher using the specified libpod API
+// client; typically, you would want to use this lower-level constructor only
+// in unit tests and instead use watcher.podman.New instead in most use cases.
+func NewPodmanWatcher(client *Client, opts ...NewOption) *PodmanWatcher {
+	pw := &PodmanWatcher{
+		client: client,
+	}
+	for _, opt := range opts {
+		opt(pw)
+	}
+	return pw
+}
+
+// NewOption represents options to NewPodmanWatcher when creating new watchers
+// keeping eyes on Podman services.
+type NewOption func(*PodmanWatcher)
+
+// WithPID sets the engine's PID when known.
+func WithPID(pid int) NewOption {
+	return func(pw *PodmanWatcher) {
+		pw.pid = pid
+	}
+}
+
+// WithRucksackPacker sets the Rucksack packer that adds application-specific
+// container information based on the inspected container data. The specified
+// Rucksack packer gets passed the inspection data in form of a
+// *ContainerInspect. Please consider using [WithTypedRucksackPacker] instead.
+func WithRucksackPacker(packer engineclient.RucksackPacker) NewOption {
+	return func(pw *PodmanWatcher) {
+		pw.packer = packer
+	}
+}
+
+// WithTypedRucksackPacker sets the Rucksack packer that adds
+// application-specific container information based on the inspected container
+// data, with the inspection data type being checked at compile time.
+func WithTypedRucksackPacker(packer engineclient.TypedRucksackPacker[*ContainerInspect]) NewOption {
+	return WithRucksackPacker(engineclient.Untyped(packer))
+}
+
+// ID returns the (more or less) unique engine identifier. As Podman doesn't
+// have any notion of an engine identifier, we synthesize one from the host
+// name and the storage location, so that the rootful and rootless Podman
+// services of the same host get different identifiers.
+func (pw *PodmanWatcher) ID(ctx context.Context) string {
+	info, err := pw.client.Info(ctx)
+	if err != nil {
+		return ""
+	}
+	return info.Host.Hostname + ":" + info.Store.GraphRoot
+}
+
+// Type returns the type identifier for this container engine.
+func (pw *PodmanWatcher) Type() string { return Type }
+
+// Version information about the engine.
+func (pw *PodmanWatcher) Version(ctx context.Context) string {
+	info, err := pw.client.Info(ctx)
+	if err != nil {
+		return ""
+	}
+	return info.Version.Version
+}
+
+// API returns the container engine API path.
+func (pw *PodmanWatcher) API() string { return pw.client.Host() }
+
+// PID returns the container engine PID, when known.
+func (pw *PodmanWatcher) PID() int { return pw.pid }
+
+// Client returns the underlying engine client (engine-specific).
+func (pw *PodmanWatcher) Client() any { return pw.client }
+
+// Close cleans up and release any engine client resources, if necessary.
+func (pw *PodmanWatcher) Close() {
+	_ = pw.client.Close()
+}
+
+// List all the currently alive and kicking containers, but do not list any
+// containers without any processes.
+func (pw *PodmanWatcher) List(ctx context.Context) ([]*whalewatcher.Container, error) {
+	containers, err := pw.client.ContainerList(ctx)
+	if err != nil {
+		return nil, err // list? what list??
+	}
+	podnames := map[string]string{}
+	alives := make([]*whalewatcher.Container, 0, len(containers))
+	for _, container := range containers {
+		alive, err := pw.inspect(ctx, container.ID, podnames)
+		if err != nil {
+			// silently ignore missing containers that have gone since the list
+			// was prepared, but abort on severe problems in order to not keep
+			// this running for too long unnecessarily.
+			if !engineclient.IsProcesslessContainer(err) && !IsNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+		alives = append(alives, alive)
+	}
+	return alives, nil
+}
+
+// Inspect (only) those container details of interest to us, given the name or
+// ID of a container. If inspection fails, it returns an error instead.
+func (pw *PodmanWatcher) Inspect(ctx context.Context, nameorid string) (*whalewatcher.Container, error) {
+	return pw.inspect(ctx, nameorid, map[string]string{})
+}
+
+// inspect the container with the specified name or ID, caching pod names in
+// the specified map of pod IDs to pod names.
+func (pw *PodmanWatcher) inspect(ctx context.Context, nameorid string, podnames map[string]string) (*whalewatcher.Container, error) {
+	details, err := pw.client.ContainerInspect(ctx, nameorid)
+	if err != nil {
+		return nil, err
+	}
+	if details.State.Pid == 0 {
+		return nil, engineclient.NewProcesslessContainerError(nameorid, "Podman")
+	}
+	labels := maps.Clone(details.Config.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	project := labels[ComposerProjectLabel]
+	// Containers in pods belong to the project named after their pod.
+	if details.Pod != "" {
+		podname, ok := podnames[details.Pod]
+		if !ok {
+			pod, err := pw.client.PodInspect(ctx, details.Pod)
+			if err != nil {
+				return nil, err
+			}
+			podname = pod.Name
+			podnames[details.Pod] = podname
+		}
+		project = podname
+		labels[PodNameLabel] = podname
+		labels[PodIDLabel] = details.Pod
+	}
+	// Just the presence of these "magic" labels is sufficient; their values
+	// don't matter.
+	if details.IsInfra {
+		labels[InfraLabel] = ""
+	}
+	if details.HostConfig.Privileged {
+		labels[PrivilegedLabel] = ""
+	}
+	cntr := &whalewatcher.Container{
+		ID:      details.ID,
+		Name:    details.Name,
+		Labels:  labels,
+		PID:     details.State.Pid,
+		Project: project,
+		Paused:  details.State.Paused,
+	}
+	if !details.State.StartedAt.IsZero() {
+		cntr.StartedAt = details.State.StartedAt
+	}
+	// If someone wants to keep more details, let them pack it into the Rucksack
+	// of the container description.
+	if pw.packer != nil {
+		pw.packer.Pack(cntr, details)
+	}
+	return cntr, nil
+}
+
+// LifecycleEvents streams container engine events, limited just to those events
+// in the lifecycle of containers getting born (=alive, as opposed to, say,
+// "conceived") and die, as well as informational lifecycle events, such as
+// containers getting created and removed. As libpod events only carry the IDs
+// of pods, but not their names, the projects of all events are unknown. Exit
+// events carry the exit code and whether the container was OOM-killed.
+func (pw *PodmanWatcher) LifecycleEvents(ctx context.Context) (<-chan engineclient.ContainerEvent, <-chan error) {
+	cntreventstream := make(chan engineclient.ContainerEvent)
+	cntrerrstream := make(chan error, 1)
+
+	go func() {
+		defer close(cntrerrstream)
+		evs, errs := pw.client.Events(ctx, "start", "died", "pause", "unpause",
+			"create", "remove", "kill", "oom", "restart", "stop")
+		// Podman emits separate "oom" events before the "died" events of
+		// OOM-killed containers, so remember them until the containers die,
+		// or are (re)started, or get removed.
+		oomkilled := map[string]struct{}{}
+		for ev := range evs {
+			cntrev := engineclient.ContainerEvent{
+				Timestamp: time.Unix(0, ev.TimeNano),
+				ID:        ev.Actor.ID,
+				Project:   engineclient.ProjectUnknown,
+			}
+			switch ev.Action {
+			case "start":
+				cntrev.Type = engineclient.ContainerStarted
+				delete(oomkilled, ev.Actor.ID)
+			case "died", "die":
+				cntrev.Type = engineclient.ContainerExited
+				exitcode, _ := strconv.Atoi(ev.Actor.Attributes["containerExitCode"])
+				_, oom := oomkilled[ev.Actor.ID]
+				delete(oomkilled, ev.Actor.ID)
+				cntrev.Exit = &engineclient.ExitInfo{
+					ExitCode:  exitcode,
+					ExitedAt:  cntrev.Timestamp,
+					OOMKilled: oom,
+				}
+			case "pause":
+				cntrev.Type = engineclient.ContainerPaused
+			case "unpause":
+				cntrev.Type = engineclient.ContainerUnpaused
+			case "create":
+				cntrev.Type = engineclient.ContainerCreated
+			case "remove":
+				cntrev.Type = engineclient.ContainerRemoved
+				delete(oomkilled, ev.Actor.ID)
+			case "kill":
+				cntrev.Type = engineclient.ContainerKilled
+			case "oom":
+				cntrev.Type = engineclient.ContainerOOMKilled
+				oomkilled[ev.Actor.ID] = struct{}{}
+			case "restart":
+				cntrev.Type = engineclient.ContainerRestarting
+				delete(oomkilled, ev.Actor.ID)
+			case "stop":
+				cntrev.Type = engineclient.ContainerStopped
+			default:
+				continue
+			}
+			select {
+			case cntreventstream <- cntrev:
+			case <-ctx.Done():
+			}
+		}
+		err := <-errs
+		// Let a cancelled context take priority over any other event stream
+		// error, as the latter is usually just a consequence.
+		if ctx.Err() == context.Canceled {
+			err = ctx.Err()
+		}
+		cntrerrstream <- err
+	}()
+
+	return cntreventstream, cntrerrstream
+}
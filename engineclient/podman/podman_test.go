@@ -0,0 +1,292 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+	. "github.com/thediveo/whalewatcher/v2/test/matcher"
+	"github.com/thediveo/whalewatcher/v2/test/mockingpodman"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var (
+	podPodgy = mockingpodman.MockedPod{
+		ID:   "pod-4242",
+		Name: "podgy",
+	}
+
+	furiousFuruncle = mockingpodman.MockedContainer{
+		ID:     "6666666666",
+		Name:   "furious_furuncle",
+		PID:    666,
+		Labels: map[string]string{ComposerProjectLabel: "testproject"},
+	}
+
+	podgyInfra = mockingpodman.MockedContainer{
+		ID:    "4242424242",
+		Name:  "podgy-infra",
+		PID:   4242,
+		Pod:   podPodgy.ID,
+		Infra: true,
+	}
+
+	podgyPodder = mockingpodman.MockedContainer{
+		ID:         "4343434343",
+		Name:       "podgy-podder",
+		PID:        4343,
+		Pod:        podPodgy.ID,
+		Labels:     map[string]string{ComposerProjectLabel: "testproject"},
+		Privileged: true,
+	}
+
+	deadDummy = mockingpodman.MockedContainer{
+		ID:   "1234567890",
+		Name: "dead_dummy",
+	}
+)
+
+var _ = Describe("podman engineclient", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	var mp *mockingpodman.MockingPodman
+	var ec *PodmanWatcher
+
+	BeforeEach(func() {
+		mp = Successful(mockingpodman.New())
+		DeferCleanup(mp.Close)
+		ec = NewPodmanWatcher(Successful(NewClient(mp.Host())), WithPID(123456))
+		DeferCleanup(ec.Close)
+		Expect(ec.PID()).To(Equal(123456))
+		mp.AddPod(podPodgy)
+		mp.AddContainer(furiousFuruncle)
+	})
+
+	It("has engine type ID, API path, and client", func() {
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.API()).To(Equal(mp.Host()))
+		Expect(ec.Client()).To(BeIdenticalTo(ec.client))
+	})
+
+	It("has an ID and version", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+		Expect(ec.ID(ctx)).To(Equal("mockinghost:/var/lib/containers/storage"))
+		Expect(ec.Version(ctx)).NotTo(BeEmpty())
+		cancel()
+		Expect(ec.ID(ctx)).To(BeZero())
+		Expect(ec.Version(ctx)).To(BeZero())
+	})
+
+	It("cannot inspect a dead or missing container", func(ctx context.Context) {
+		mp.AddContainer(deadDummy)
+		Expect(ec.Inspect(ctx, deadDummy.ID)).Error().To(Satisfy(engineclient.IsProcesslessContainer))
+		Expect(ec.Inspect(ctx, "foobar")).Error().To(Satisfy(IsNotFound))
+	})
+
+	It("inspects a furuncle", func(ctx context.Context) {
+		cntr := Successful(ec.Inspect(ctx, furiousFuruncle.Name))
+		Expect(cntr).To(And(
+			HaveID(furiousFuruncle.ID),
+			HaveName(furiousFuruncle.Name),
+			HaveProject("testproject"),
+		))
+		Expect(cntr.PID).To(Equal(furiousFuruncle.PID))
+		Expect(cntr.Labels).NotTo(HaveKey(PodNameLabel))
+	})
+
+	It("maps pods to projects", func(ctx context.Context) {
+		mp.AddContainer(podgyInfra)
+		mp.AddContainer(podgyPodder)
+
+		infra := Successful(ec.Inspect(ctx, podgyInfra.ID))
+		Expect(infra).To(HaveProject(podPodgy.Name))
+		Expect(infra.Labels).To(And(
+			HaveKeyWithValue(PodNameLabel, podPodgy.Name),
+			HaveKeyWithValue(PodIDLabel, podPodgy.ID),
+			HaveKey(InfraLabel),
+			Not(HaveKey(PrivilegedLabel)),
+		))
+
+		podder := Successful(ec.Inspect(ctx, podgyPodder.ID))
+		Expect(podder).To(HaveProject(podPodgy.Name))
+		Expect(podder.Labels).To(And(
+			HaveKeyWithValue(ComposerProjectLabel, "testproject"),
+			HaveKey(PrivilegedLabel),
+			Not(HaveKey(InfraLabel)),
+		))
+	})
+
+	It("inspects using a typed rucksack packer", func(ctx context.Context) {
+		WithTypedRucksackPacker(engineclient.RucksackPackerFunc[*ContainerInspect](
+			func(container *whalewatcher.Container, inspection *ContainerInspect) {
+				container.Rucksack = inspection.ID
+			}))(ec)
+		cntr := Successful(ec.Inspect(ctx, furiousFuruncle.ID))
+		Expect(whalewatcher.RucksackOf[string](cntr)).To(Equal(furiousFuruncle.ID))
+	})
+
+	It("lists containers", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		mp.AddContainer(podgyPodder)
+		mp.AddContainer(deadDummy)
+		Expect(ec.List(ctx)).To(ConsistOf(
+			And(HaveID(furiousFuruncle.ID), HaveProject("testproject")),
+			And(HaveID(podgyPodder.ID), HaveProject(podPodgy.Name)),
+		))
+
+		cancel()
+		Expect(ec.List(ctx)).Error().To(HaveOccurred())
+	})
+
+	It("watches containers come and go", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Expect(evs).NotTo(BeNil())
+		Expect(errs).NotTo(BeNil())
+		Eventually(mp.Subscribers).Should(Equal(1))
+
+		Consistently(evs).ShouldNot(Receive())
+		Consistently(errs).ShouldNot(Receive())
+
+		By("adding a new container")
+		mp.AddContainer(podgyPodder)
+		Eventually(evs).Should(Receive(And(
+			HaveTimestamp(Not(BeZero())),
+			HaveID(podgyPodder.ID),
+			HaveEventType(engineclient.ContainerStarted),
+			HaveProject(engineclient.ProjectUnknown),
+		)))
+
+		By("pausing the container")
+		mp.PauseContainer(podgyPodder.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(podgyPodder.ID),
+			HaveEventType(engineclient.ContainerPaused),
+		)))
+
+		By("unpausing the container")
+		mp.UnpauseContainer(podgyPodder.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(podgyPodder.ID),
+			HaveEventType(engineclient.ContainerUnpaused),
+		)))
+
+		By("stopping the container")
+		mp.StopContainer(podgyPodder.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(podgyPodder.ID),
+			HaveEventType(engineclient.ContainerExited),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("reports exit information", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Eventually(mp.Subscribers).Should(Equal(1))
+
+		startedAt := time.Date(2026, 10, 16, 12, 34, 56, 0, time.UTC)
+		oomer := podgyPodder
+		oomer.StartedAt = startedAt
+		oomer.ExitCode = 137
+		mp.AddContainer(oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerStarted)))
+		cntr := Successful(ec.Inspect(ctx, oomer.ID))
+		Expect(cntr.StartedAt).To(BeTemporally("==", startedAt))
+
+		mp.EmitEvent("oom", oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerOOMKilled)))
+		mp.StopContainer(oomer.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(oomer.ID),
+			HaveEventType(engineclient.ContainerExited),
+			HaveField("Exit.ExitCode", 137),
+			HaveField("Exit.ExitedAt", Not(BeZero())),
+			HaveField("Exit.OOMKilled", BeTrue()),
+		)))
+
+		By("getting OOM-killed, restarted, and then gracefully stopped")
+		mp.AddContainer(oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerStarted)))
+		mp.EmitEvent("oom", oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerOOMKilled)))
+		mp.EmitEvent("restart", oomer)
+		Eventually(evs).Should(Receive(HaveEventType(engineclient.ContainerRestarting)))
+		mp.StopContainer(oomer.ID)
+		Eventually(evs).Should(Receive(And(
+			HaveID(oomer.ID),
+			HaveEventType(engineclient.ContainerExited),
+			HaveField("Exit.OOMKilled", BeFalse()),
+		)))
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+	It("passes on informational lifecycle events", func(ctx context.Context) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		evs, errs := ec.LifecycleEvents(ctx)
+		Eventually(mp.Subscribers).Should(Equal(1))
+
+		for _, action := range []struct {
+			action string
+			evtype engineclient.ContainerEventType
+		}{
+			{"create", engineclient.ContainerCreated},
+			{"kill", engineclient.ContainerKilled},
+			{"oom", engineclient.ContainerOOMKilled},
+			{"restart", engineclient.ContainerRestarting},
+			{"stop", engineclient.ContainerStopped},
+			{"remove", engineclient.ContainerRemoved},
+		} {
+			By("emitting a " + action.action + " event")
+			mp.EmitEvent(action.action, podgyPodder)
+			Eventually(evs).Should(Receive(And(
+				HaveID(podgyPodder.ID),
+				HaveEventType(action.evtype),
+				HaveProject(engineclient.ProjectUnknown),
+			)))
+		}
+
+		By("ignoring other events")
+		mp.EmitEvent("rename", podgyPodder)
+		Consistently(evs).ShouldNot(Receive())
+
+		cancel()
+		Eventually(errs).Should(Receive(Equal(ctx.Err())))
+	})
+
+})
@@ -0,0 +1,65 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+)
+
+// Scheme is the name of the engine URL scheme for Podman services, such as
+// "podman:///run/podman/podman.sock", "podman+unix:///run/podman/podman.sock",
+// or "podman+tcp://localhost:8080". With an empty path, [DefaultHost] applies.
+// The only query parameter is "pid".
+const Scheme = "podman"
+
+func init() {
+	engineclient.Register(Scheme, open)
+}
+
+// open returns a new PodmanWatcher configured by the specified engine URL.
+func open(u *url.URL) (engineclient.EngineClient, error) {
+	query, err := engineclient.Query(u, "pid")
+	if err != nil {
+		return nil, err
+	}
+	pid, err := engineclient.QueryPID(query)
+	if err != nil {
+		return nil, err
+	}
+	var host string
+	switch _, transport := engineclient.SplitScheme(u.Scheme); transport {
+	case "tcp":
+		if u.Host == "" {
+			return nil, fmt.Errorf("engine URL %q lacks host", u.Redacted())
+		}
+		host = "tcp://" + u.Host
+	default:
+		sockpath, err := engineclient.SocketPath(u)
+		if err != nil {
+			return nil, err
+		}
+		if sockpath != "" {
+			host = "unix://" + sockpath
+		}
+	}
+	client, err := NewClient(host)
+	if err != nil {
+		return nil, err
+	}
+	return NewPodmanWatcher(client, WithPID(pid)), nil
+}
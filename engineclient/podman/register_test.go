@@ -0,0 +1,53 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+	"github.com/thediveo/whalewatcher/v2/test/mockingpodman"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Podman engine URLs", func() {
+
+	It("opens engine URLs", func(ctx context.Context) {
+		mp := Successful(mockingpodman.New())
+		defer mp.Close()
+
+		ec := Successful(engineclient.Open("podman+" + mp.Host() + "?pid=42"))
+		defer ec.Close()
+		Expect(ec.Type()).To(Equal(Type))
+		Expect(ec.PID()).To(Equal(42))
+		Expect(ec.API()).To(Equal(mp.Host()))
+		Expect(ec.ID(ctx)).NotTo(BeEmpty())
+
+		ec = Successful(engineclient.Open("podman+tcp://localhost:1234"))
+		defer ec.Close()
+		Expect(ec.API()).To(Equal("tcp://localhost:1234"))
+	})
+
+	It("rejects invalid engine URLs", func() {
+		Expect(engineclient.Open("podman+tcp:///run/podman/podman.sock")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("podman:///run/podman/podman.sock?foo=bar")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("podman:///run/podman/podman.sock?pid=foo")).Error().To(HaveOccurred())
+		Expect(engineclient.Open("podman+udp:///run/podman/podman.sock")).Error().To(HaveOccurred())
+	})
+
+})
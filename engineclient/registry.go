@@ -0,0 +1,146 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engineclient
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Factory returns a new EngineClient configured by the specified engine URL.
+// The URL's scheme consists of the name the factory has been registered with,
+// optionally followed by a "+" and the transport, such as "docker+unix".
+// Engine-specific settings are passed as query parameters.
+type Factory func(u *url.URL) (EngineClient, error)
+
+var (
+	factoriesmu sync.RWMutex
+	factories   = map[string]Factory{}
+)
+
+// Register the factory for the specified URL scheme name, such as "docker" or
+// "containerd". The engine client packages register their factories when
+// getting imported. Register panics if the same name is registered twice or
+// if the factory is nil.
+func Register(name string, factory Factory) {
+	factoriesmu.Lock()
+	defer factoriesmu.Unlock()
+	if factory == nil {
+		panic("engineclient: Register factory is nil")
+	}
+	if _, dup := factories[name]; dup {
+		panic("engineclient: Register called twice for " + name)
+	}
+	factories[name] = factory
+}
+
+// Schemes returns the sorted names of the registered URL schemes.
+func Schemes() []string {
+	factoriesmu.RLock()
+	defer factoriesmu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Open returns a new EngineClient for the engine specified by the URL, such as
+// "containerd:///run/containerd/containerd.sock?ignore=moby,k8s.io" or
+// "docker+unix:///run/docker.sock", using the factory registered for the
+// URL's scheme name. Please note that the factory of an engine type gets only
+// registered when its engine client package is imported.
+func Open(rawurl string) (EngineClient, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	name, _ := SplitScheme(u.Scheme)
+	factoriesmu.RLock()
+	factory := factories[name]
+	factoriesmu.RUnlock()
+	if factory == nil {
+		return nil, fmt.Errorf("unknown engine URL scheme %q", u.Scheme)
+	}
+	return factory(u)
+}
+
+// SplitScheme splits a URL scheme into its name and transport parts, such as
+// "docker" and "unix" for "docker+unix". If the scheme doesn't specify any
+// transport, the transport is empty.
+func SplitScheme(scheme string) (name string, transport string) {
+	name, transport, _ = strings.Cut(scheme, "+")
+	return
+}
+
+// SocketPath returns the unix socket path of the specified engine URL, which
+// might be empty if the engine's default applies. It returns an error if the
+// URL specifies a transport other than "unix" or a host.
+func SocketPath(u *url.URL) (string, error) {
+	if _, transport := SplitScheme(u.Scheme); transport != "" && transport != "unix" {
+		return "", fmt.Errorf("unsupported engine URL transport %q", transport)
+	}
+	if u.Host != "" || u.Opaque != "" {
+		return "", fmt.Errorf("engine URL %q must not specify a host", u.Redacted())
+	}
+	return u.Path, nil
+}
+
+// Query returns the query parameters of the specified engine URL, returning an
+// error if there are other than the allowed parameters.
+func Query(u *url.URL, allowed ...string) (url.Values, error) {
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+	for param := range query {
+		if !slices.Contains(allowed, param) {
+			return nil, fmt.Errorf("unsupported engine URL parameter %q", param)
+		}
+	}
+	return query, nil
+}
+
+// QueryPID returns the engine PID specified by the "pid" query parameter, or
+// zero if not specified.
+func QueryPID(query url.Values) (int, error) {
+	if !query.Has("pid") {
+		return 0, nil
+	}
+	pid, err := strconv.Atoi(query.Get("pid"))
+	if err != nil || pid < 0 {
+		return 0, fmt.Errorf("invalid engine URL pid parameter %q", query.Get("pid"))
+	}
+	return pid, nil
+}
+
+// QueryList returns the list of values of the specified query parameter, which
+// might be either comma-separated or repeated, or both.
+func QueryList(query url.Values, param string) []string {
+	var list []string
+	for _, value := range query[param] {
+		for item := range strings.SplitSeq(value, ",") {
+			if item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package engineclient
+
+import (
+	"errors"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("engine URL registry", func() {
+
+	It("registers and opens engine URLs", func() {
+		var opened *url.URL
+		Register("fooengine", func(u *url.URL) (EngineClient, error) {
+			opened = u
+			return nil, errors.New("D'OH!")
+		})
+		DeferCleanup(func() {
+			factoriesmu.Lock()
+			defer factoriesmu.Unlock()
+			delete(factories, "fooengine")
+		})
+		Expect(Schemes()).To(ContainElement("fooengine"))
+
+		Expect(func() { Register("fooengine", func(*url.URL) (EngineClient, error) { return nil, nil }) }).
+			To(PanicWith(ContainSubstring("twice")))
+		Expect(func() { Register("barengine", nil) }).To(PanicWith(ContainSubstring("nil")))
+
+		Expect(Open("fooengine+unix:///run/foo.sock?pid=42")).Error().To(MatchError("D'OH!"))
+		Expect(opened).NotTo(BeNil())
+		Expect(opened.Scheme).To(Equal("fooengine+unix"))
+		Expect(opened.Path).To(Equal("/run/foo.sock"))
+
+		Expect(Open("barengine:///run/bar.sock")).Error().To(MatchError(ContainSubstring("unknown engine URL scheme")))
+		Expect(Open(":")).Error().To(HaveOccurred())
+	})
+
+	It("splits schemes", func() {
+		name, transport := SplitScheme("docker+unix")
+		Expect(name).To(Equal("docker"))
+		Expect(transport).To(Equal("unix"))
+		name, transport = SplitScheme("cri")
+		Expect(name).To(Equal("cri"))
+		Expect(transport).To(BeEmpty())
+	})
+
+	It("returns socket paths", func() {
+		Expect(SocketPath(Successful(url.Parse("foo:///run/foo.sock")))).To(Equal("/run/foo.sock"))
+		Expect(SocketPath(Successful(url.Parse("foo+unix:///run/foo.sock")))).To(Equal("/run/foo.sock"))
+		Expect(SocketPath(Successful(url.Parse("foo://")))).To(BeEmpty())
+		Expect(SocketPath(Successful(url.Parse("foo+tcp://localhost:1234")))).Error().
+			To(MatchError(ContainSubstring("unsupported engine URL transport")))
+		Expect(SocketPath(Successful(url.Parse("foo://localhost/run/foo.sock")))).Error().
+			To(MatchError(ContainSubstring("must not specify a host")))
+	})
+
+	It("checks and returns query parameters", func() {
+		u := Successful(url.Parse("foo:///run/foo.sock?pid=42&ignore=moby,k8s.io&ignore=default"))
+		Expect(Query(u, "pid")).Error().To(MatchError(ContainSubstring(`unsupported engine URL parameter "ignore"`)))
+		query := Successful(Query(u, "pid", "ignore"))
+		Expect(QueryPID(query)).To(Equal(42))
+		Expect(QueryList(query, "ignore")).To(ConsistOf("moby", "k8s.io", "default"))
+		Expect(QueryList(query, "foo")).To(BeEmpty())
+
+		Expect(QueryPID(url.Values{})).To(BeZero())
+		Expect(QueryPID(url.Values{"pid": {"-1"}})).Error().To(HaveOccurred())
+		Expect(QueryPID(url.Values{"pid": {"foo"}})).Error().To(HaveOccurred())
+
+		Expect(Query(&url.URL{RawQuery: "%"})).Error().To(HaveOccurred())
+	})
+
+})
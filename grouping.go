@@ -0,0 +1,60 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+// Grouping returns the name of the group a container belongs to, where the
+// zero/empty name stands for the "zero" group of ungrouped containers. A
+// [Portfolio] organizes its containers into groups using its Grouping, with
+// each group being represented by a [ComposerProject] of the same name.
+//
+// Groupings must always return the same group name for the same container and
+// must only depend on the immutable container information, such as its
+// project and labels.
+type Grouping func(cntr *Container) string
+
+// GroupByProject groups containers by their composer projects; this is the
+// default grouping of portfolios.
+func GroupByProject(cntr *Container) string {
+	return cntr.Project
+}
+
+// GroupByLabel returns a Grouping that groups containers by the value of the
+// specified label. Containers without this label end up in the "zero" group.
+func GroupByLabel(key string) Grouping {
+	return func(cntr *Container) string {
+		return cntr.Labels[key]
+	}
+}
+
+// PortfolioOption represents options to NewPortfolio when creating new
+// portfolios.
+type PortfolioOption func(*Portfolio)
+
+// WithGrouping sets the grouping of containers in a portfolio, defaulting to
+// [GroupByProject].
+func WithGrouping(grouping Grouping) PortfolioOption {
+	return func(pf *Portfolio) {
+		pf.grouping = grouping
+	}
+}
+
+// group returns the name of the group the specified container belongs to in
+// this portfolio.
+func (pf *Portfolio) group(cntr *Container) string {
+	if pf.grouping == nil {
+		return GroupByProject(cntr)
+	}
+	return pf.grouping(cntr)
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("grouping containers", func() {
+
+	It("groups by project by default", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy",
+			Labels: map[string]string{"tier": "web"}})
+		Expect(pf.Names()).To(ConsistOf("grumpy"))
+	})
+
+	It("groups by label", func() {
+		pf := NewPortfolio(WithGrouping(GroupByLabel("tier")))
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy",
+			Labels: map[string]string{"tier": "web"}})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", Project: "grumpy",
+			Labels: map[string]string{"tier": "db"}})
+		pf.Add(&Container{ID: "3", Name: "pompous_paperboard",
+			Labels: map[string]string{"tier": "web"}})
+		pf.Add(&Container{ID: "4", Name: "loose_lumberjack", Project: "grumpy"})
+
+		Expect(pf.Names()).To(ConsistOf("web", "db"))
+		Expect(pf.Project("web").ContainerNames()).To(ConsistOf("furious_furuncle", "pompous_paperboard"))
+		Expect(pf.Project("").ContainerNames()).To(ConsistOf("loose_lumberjack"))
+
+		By("pausing and removing containers using their composer projects")
+		Expect(pf.SetPaused("furious_furuncle", "web", true)).To(BeNil())
+		Expect(pf.SetPaused("furious_furuncle", "grumpy", true)).To(HaveField("Paused", true))
+		Expect(pf.Project("web").Container("furious_furuncle")).To(HaveField("Paused", true))
+		Expect(pf.SetPaused("furious_furuncle", "grumpy", true)).To(HaveField("Paused", true))
+
+		Expect(pf.Remove("murky_moby", "db")).To(BeNil())
+		Expect(pf.Remove("2", "grumpy")).To(HaveField("Name", "murky_moby"))
+		Expect(pf.Names()).To(ConsistOf("web"))
+		Expect(pf.Remove("loose_lumberjack", "grumpy")).NotTo(BeNil())
+		Expect(pf.Project("")).NotTo(BeNil())
+
+		By("round-tripping via JSON")
+		j := Successful(json.Marshal(pf))
+		Expect(UnmarshalPortfolio(j, nil)).Error().To(MatchError(ContainSubstring(
+			`of project "grumpy" listed in project "web"`)))
+		pf2 := Successful(UnmarshalPortfolio(j, nil, WithGrouping(GroupByLabel("tier"))))
+		Expect(pf2.Project("web").ContainerNames()).To(ConsistOf("furious_furuncle", "pompous_paperboard"))
+	})
+
+	It("disambiguates containers with the same name", func() {
+		pf := NewPortfolio(WithGrouping(GroupByLabel("tier")))
+		pf.Add(&Container{ID: "1", Name: "moby", Project: "grumpy", Labels: map[string]string{"tier": "web"}})
+		pf.Add(&Container{ID: "2", Name: "moby", Project: "dopey", Labels: map[string]string{"tier": "web"}})
+
+		Expect(pf.Remove("moby", "dopey")).To(HaveField("ID", "2"))
+		Expect(pf.Project("web").ContainerNames()).To(ConsistOf("moby"))
+		Expect(pf.Container("moby")).To(HaveField("ID", "1"))
+	})
+
+})
@@ -0,0 +1,85 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import "slices"
+
+// multiIndex indexes containers by some key. As container names aren't
+// necessarily unique (think of Kubernetes) and containerd doesn't enforce
+// unique IDs across container lifetimes, a key might index multiple
+// containers.
+type multiIndex[K comparable] map[K][]*Container
+
+// add the container under the specified key.
+func (mi multiIndex[K]) add(key K, cntr *Container) {
+	mi[key] = append(mi[key], cntr)
+}
+
+// remove the container from the specified key, if present.
+func (mi multiIndex[K]) remove(key K, cntr *Container) {
+	cntrs := mi[key]
+	idx := slices.Index(cntrs, cntr)
+	if idx < 0 {
+		return
+	}
+	if len(cntrs) == 1 {
+		delete(mi, key)
+		return
+	}
+	mi[key] = slices.Delete(cntrs, idx, idx+1)
+}
+
+// first returns the first container indexed under the specified key, or nil.
+func (mi multiIndex[K]) first(key K) *Container {
+	if cntrs := mi[key]; len(cntrs) > 0 {
+		return cntrs[0]
+	}
+	return nil
+}
+
+// containerIndex indexes containers by their IDs and names.
+type containerIndex struct {
+	ids   multiIndex[string]
+	names multiIndex[string]
+}
+
+// newContainerIndex returns a new, empty container index.
+func newContainerIndex() containerIndex {
+	return containerIndex{
+		ids:   multiIndex[string]{},
+		names: multiIndex[string]{},
+	}
+}
+
+// add the specified container to the index.
+func (ci containerIndex) add(cntr *Container) {
+	ci.ids.add(cntr.ID, cntr)
+	ci.names.add(cntr.Name, cntr)
+}
+
+// remove the specified container from the index.
+func (ci containerIndex) remove(cntr *Container) {
+	ci.ids.remove(cntr.ID, cntr)
+	ci.names.remove(cntr.Name, cntr)
+}
+
+// lookup returns the container with the specified ID or, failing that, with
+// the specified name. It returns nil if there is no such container.
+func (ci containerIndex) lookup(nameorid string) *Container {
+	if cntr := ci.ids.first(nameorid); cntr != nil {
+		return cntr
+	}
+	return ci.names.first(nameorid)
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("container indices", func() {
+
+	It("indexes multiple containers per key", func() {
+		mi := multiIndex[string]{}
+		c1 := &Container{Name: "furious_furuncle", ID: "1"}
+		c2 := &Container{Name: "furious_furuncle", ID: "2"}
+		mi.add("furious_furuncle", c1)
+		mi.add("furious_furuncle", c2)
+		Expect(mi.first("furious_furuncle")).To(BeIdenticalTo(c1))
+		Expect(mi.first("mad_moby")).To(BeNil())
+
+		mi.remove("furious_furuncle", &Container{})
+		mi.remove("mad_moby", c1)
+		Expect(mi["furious_furuncle"]).To(HaveLen(2))
+
+		mi.remove("furious_furuncle", c1)
+		Expect(mi.first("furious_furuncle")).To(BeIdenticalTo(c2))
+		mi.remove("furious_furuncle", c2)
+		Expect(mi).To(BeEmpty())
+	})
+
+	It("prefers IDs over names", func() {
+		ci := newContainerIndex()
+		c1 := &Container{Name: "2", ID: "1"}
+		c2 := &Container{Name: "furious_furuncle", ID: "2"}
+		ci.add(c1)
+		ci.add(c2)
+		Expect(ci.lookup("2")).To(BeIdenticalTo(c2))
+		Expect(ci.lookup("1")).To(BeIdenticalTo(c1))
+		ci.remove(c2)
+		Expect(ci.lookup("2")).To(BeIdenticalTo(c1))
+		Expect(ci.lookup("furious_furuncle")).To(BeNil())
+	})
+
+})
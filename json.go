@@ -0,0 +1,353 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RucksackUnpacker optionally restores the Rucksacks of containers when
+// unmarshalling them from JSON. It gets passed the unmarshalled container
+// (sans Rucksack) and the JSON representation of the container's Rucksack, and
+// then is responsible for setting the container's Rucksack.
+type RucksackUnpacker interface {
+	UnpackRucksack(container *Container, data json.RawMessage) error
+}
+
+// RucksackUnpackerFunc is an adapter allowing ordinary functions to be used
+// as RucksackUnpackers.
+type RucksackUnpackerFunc func(container *Container, data json.RawMessage) error
+
+// UnpackRucksack calls fn(container, data).
+func (fn RucksackUnpackerFunc) UnpackRucksack(container *Container, data json.RawMessage) error {
+	return fn(container, data)
+}
+
+// containerJSON is the JSON representation of a Container.
+type containerJSON struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	PID      int               `json:"pid"`
+	Project  string            `json:"project,omitempty"`
+	Paused   bool              `json:"paused,omitempty"`
+	Health   string            `json:"health,omitempty"`
+	Rucksack json.RawMessage   `json:"rucksack,omitempty"`
+
+	StartedAt  time.Time  `json:"started,omitzero"`
+	Namespaces Namespaces `json:"namespaces,omitempty"`
+	Cgroup     *Cgroup    `json:"cgroup,omitempty"`
+}
+
+// projectJSON is the JSON representation of a ComposerProject.
+type projectJSON struct {
+	Name       string       `json:"name"`
+	Containers []*Container `json:"containers"`
+}
+
+// portfolioJSON is the JSON representation of a Portfolio.
+type portfolioJSON struct {
+	Projects []projectJSON `json:"projects"`
+}
+
+// MarshalJSON returns the JSON representation of this container, using the
+// following schema:
+//
+//	{
+//	  "id": "...",                 // container ID
+//	  "name": "...",               // container name
+//	  "labels": { "key": "...", }, // optional labels
+//	  "pid": 42,                   // PID of initial container process
+//	  "project": "...",            // optional composer project name
+//	  "paused": true,              // optional, only if paused
+//	  "health": "...",             // optional health status
+//	  "rucksack": ...,             // optional Rucksack JSON representation
+//	  "started": "...",            // optional RFC 3339 start time
+//	  "namespaces": { "net": 42, }, // optional namespace inode numbers
+//	  "cgroup": {                   // optional cgroup
+//	    "path": "...",              // path relative to cgroup hierarchy root
+//	    "dir": "...",               // absolute cgroup directory
+//	    "v2": true                  // optional, only if cgroup v2
+//	  }
+//	}
+//
+// A non-nil Rucksack gets marshalled using [encoding/json], so Rucksack types
+// can control their JSON representation by implementing [json.Marshaler].
+func (c Container) MarshalJSON() ([]byte, error) {
+	cj := containerJSON{
+		ID:      c.ID,
+		Name:    c.Name,
+		Labels:  c.Labels,
+		PID:     c.PID,
+		Project: c.Project,
+		Paused:  c.Paused,
+		Health:  c.Health,
+
+		StartedAt:  c.StartedAt,
+		Namespaces: c.Namespaces,
+		Cgroup:     c.Cgroup,
+	}
+	if c.Rucksack != nil {
+		rucksack, err := json.Marshal(c.Rucksack)
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal Rucksack of container %q: %w", c.Name, err)
+		}
+		cj.Rucksack = rucksack
+	}
+	return json.Marshal(cj)
+}
+
+// UnmarshalJSON sets this container to the information from the specified
+// JSON representation; see [Container.MarshalJSON] for the schema.
+//
+// If the container has a non-nil Rucksack before unmarshalling, then the
+// Rucksack's JSON representation gets unmarshalled into this existing
+// Rucksack, so it should be a pointer. Otherwise, the Rucksack's JSON
+// representation is kept as a [json.RawMessage] that marshals back into the
+// same JSON representation. Use [UnmarshalPortfolio] with a
+// [RucksackUnpacker] to restore Rucksacks when unmarshalling whole portfolios.
+func (c *Container) UnmarshalJSON(data []byte) error {
+	var cj containerJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+	labels := cj.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	rucksack := c.Rucksack
+	*c = Container{
+		ID:      cj.ID,
+		Name:    cj.Name,
+		Labels:  labels,
+		PID:     cj.PID,
+		Project: cj.Project,
+		Paused:  cj.Paused,
+		Health:  cj.Health,
+
+		StartedAt:  cj.StartedAt,
+		Namespaces: cj.Namespaces,
+		Cgroup:     cj.Cgroup,
+	}
+	if cj.Rucksack == nil {
+		return nil
+	}
+	if rucksack != nil {
+		if err := json.Unmarshal(cj.Rucksack, rucksack); err != nil {
+			return fmt.Errorf("cannot unmarshal Rucksack of container %q: %w", c.Name, err)
+		}
+		c.Rucksack = rucksack
+		return nil
+	}
+	c.Rucksack = cj.Rucksack
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of this composer project, using
+// the following schema, with the containers sorted by their IDs:
+//
+//	{
+//	  "name": "...",      // composer project name, or "" for the zero project
+//	  "containers": [...] // see Container.MarshalJSON
+//	}
+func (p *ComposerProject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(projectJSON{
+		Name:       p.Name,
+		Containers: sortedByID(p.Containers()),
+	})
+}
+
+// UnmarshalJSON sets this composer project to the information from the
+// specified JSON representation; see [ComposerProject.MarshalJSON] for the
+// schema. Composer projects that are part of a [Portfolio] cannot be
+// unmarshalled into; unmarshal the whole Portfolio instead.
+func (p *ComposerProject) UnmarshalJSON(data []byte) error {
+	p.m.RLock()
+	attached := p.portfolio != nil
+	p.m.RUnlock()
+	if attached {
+		return errors.New("cannot unmarshal into a composer project that is part of a portfolio")
+	}
+	var pj projectJSON
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+	if err := pj.check(GroupByProject); err != nil {
+		return err
+	}
+	p.m.Lock()
+	p.Name = pj.Name
+	p.containers = make([]*Container, 0, len(pj.Containers))
+	p.slots = map[*Container]int{}
+	p.index = newContainerIndex()
+	p.m.Unlock()
+	for _, cntr := range pj.Containers {
+		p.add(cntr)
+	}
+	return nil
+}
+
+// MarshalJSON returns the JSON representation of a consistent snapshot of this
+// portfolio, using the following schema, with the projects sorted by their
+// names:
+//
+//	{
+//	  "projects": [...] // see ComposerProject.MarshalJSON
+//	}
+//
+// The zero project is always present, even if empty. Please note that the
+// portfolio's generation is not part of the JSON representation, as
+// generations only have meaning within the same process.
+func (pf *Portfolio) MarshalJSON() ([]byte, error) {
+	return pf.Snapshot().MarshalJSON()
+}
+
+// MarshalJSON returns the JSON representation of this snapshot; please see
+// [Portfolio.MarshalJSON] for the schema.
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	names := append(s.Names(), "")
+	slices.Sort(names)
+	pfj := portfolioJSON{
+		Projects: make([]projectJSON, 0, len(names)),
+	}
+	for _, name := range names {
+		pfj.Projects = append(pfj.Projects, projectJSON{
+			Name:       name,
+			Containers: sortedByID(s.Project(name)),
+		})
+	}
+	return json.Marshal(pfj)
+}
+
+// UnmarshalJSON replaces the contents of this portfolio with the projects and
+// containers from the specified JSON representation; see
+// [Portfolio.MarshalJSON] for the schema. Container Rucksacks are kept in
+// their JSON representation; use [UnmarshalPortfolio] with a
+// [RucksackUnpacker] to restore them. The previous composer projects of this
+// portfolio become detached standalone projects.
+func (pf *Portfolio) UnmarshalJSON(data []byte) error {
+	return pf.unmarshalJSON(data, nil)
+}
+
+// UnmarshalPortfolio returns a new Portfolio from the specified JSON
+// representation; see [Portfolio.MarshalJSON] for the schema. If the
+// specified unpacker is not nil, it gets called for each container with a
+// Rucksack in order to restore the Rucksack. The new Portfolio is created
+// using the specified options, such as [WithGrouping].
+func UnmarshalPortfolio(data []byte, unpacker RucksackUnpacker, opts ...PortfolioOption) (*Portfolio, error) {
+	pf := NewPortfolio(opts...)
+	if err := pf.unmarshalJSON(data, unpacker); err != nil {
+		return nil, err
+	}
+	return pf, nil
+}
+
+// unmarshalJSON replaces the contents of this portfolio with the projects and
+// containers from the specified JSON representation, optionally unpacking
+// container Rucksacks.
+func (pf *Portfolio) unmarshalJSON(data []byte, unpacker RucksackUnpacker) error {
+	var pfj portfolioJSON
+	if err := json.Unmarshal(data, &pfj); err != nil {
+		return err
+	}
+	for _, pj := range pfj.Projects {
+		if err := pj.check(pf.group); err != nil {
+			return err
+		}
+		if unpacker == nil {
+			continue
+		}
+		for _, cntr := range pj.Containers {
+			rucksack, ok := cntr.Rucksack.(json.RawMessage)
+			if !ok {
+				continue
+			}
+			cntr.Rucksack = nil
+			if err := unpacker.UnpackRucksack(cntr, rucksack); err != nil {
+				return fmt.Errorf("cannot unpack Rucksack of container %q: %w", cntr.Name, err)
+			}
+		}
+	}
+	pf.m.Lock()
+	// Observers see the previous contents getting removed, followed by the new
+	// contents getting added.
+	var notifications []notification
+	for name, proj := range pf.projects {
+		for _, cntr := range proj.Containers() {
+			notifications = append(notifications,
+				func(o Observer) { o.ContainerRemoved(cntr) })
+		}
+		if name != "" {
+			notifications = append(notifications,
+				func(o Observer) { o.ProjectRemoved(name) })
+		}
+		// Callers might still hold on to the previous projects, so these
+		// must not touch this portfolio anymore.
+		proj.detach()
+	}
+	pf.projects = map[string]*ComposerProject{
+		"": newComposerProject(pf, ""),
+	}
+	pf.index = newContainerIndex()
+	pf.pids = multiIndex[int]{}
+	pf.namespaces = multiIndex[nsKey]{}
+	for _, pj := range pfj.Projects {
+		proj, ok := pf.projects[pj.Name]
+		if !ok {
+			proj = newComposerProject(pf, pj.Name)
+			pf.projects[pj.Name] = proj
+			notifications = append(notifications,
+				func(o Observer) { o.ProjectAdded(pj.Name) })
+		}
+		for _, cntr := range pj.Containers {
+			if proj.add(cntr) {
+				pf.indexContainer(cntr)
+				notifications = append(notifications,
+					func(o Observer) { o.ContainerAdded(cntr) })
+			}
+		}
+	}
+	pf.generation = generations.Add(1)
+	pf.unlockAndNotify(notifications...)
+	return nil
+}
+
+// check that the containers of the JSON project representation are non-nil
+// and actually belong to this project, according to the specified grouping.
+func (pj projectJSON) check(group func(*Container) string) error {
+	for _, cntr := range pj.Containers {
+		if cntr == nil {
+			return fmt.Errorf("invalid null container in project %q", pj.Name)
+		}
+		if name := group(cntr); name != pj.Name {
+			return fmt.Errorf("container %q of project %q listed in project %q",
+				cntr.Name, name, pj.Name)
+		}
+	}
+	return nil
+}
+
+// sortedByID returns the specified containers sorted by their IDs.
+func sortedByID(containers []*Container) []*Container {
+	slices.SortFunc(containers, func(a, b *Container) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return containers
+}
@@ -0,0 +1,209 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+type rucksack struct {
+	Motto string `json:"motto"`
+}
+
+var _ = Describe("JSON", func() {
+
+	var pf *Portfolio
+
+	BeforeEach(func() {
+		pf = NewPortfolio()
+		pf.Add(&Container{
+			ID:       "1",
+			Name:     "furious_furuncle",
+			Labels:   map[string]string{"foo": "bar"},
+			PID:      42,
+			Project:  "grumpy",
+			Paused:   true,
+			Rucksack: &rucksack{Motto: "I'm not dead yet"},
+		})
+		pf.Add(&Container{ID: "3", Name: "pompous_paperboard", PID: 666})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", PID: 123})
+	})
+
+	It("marshals a container", func() {
+		Expect(json.Marshal(pf.Container("furious_furuncle"))).To(MatchJSON(`{
+			"id": "1",
+			"name": "furious_furuncle",
+			"labels": {"foo": "bar"},
+			"pid": 42,
+			"project": "grumpy",
+			"paused": true,
+			"rucksack": {"motto": "I'm not dead yet"}
+		}`))
+		Expect(json.Marshal(pf.Container("murky_moby"))).To(MatchJSON(
+			`{"id": "2", "name": "murky_moby", "pid": 123}`))
+	})
+
+	It("reports Rucksack marshalling errors", func() {
+		c := &Container{Name: "furious_furuncle", Rucksack: func() {}}
+		Expect(json.Marshal(c)).Error().To(MatchError(ContainSubstring(
+			`cannot marshal Rucksack of container "furious_furuncle"`)))
+	})
+
+	It("unmarshals a container, keeping its Rucksack in JSON representation", func() {
+		var c Container
+		Expect(json.Unmarshal([]byte(`{"id":"1","name":"foo","pid":42,"rucksack":{"motto":"spam"}}`), &c)).
+			To(Succeed())
+		Expect(c.ID).To(Equal("1"))
+		Expect(c.Labels).NotTo(BeNil())
+		Expect(c.Rucksack).To(BeAssignableToTypeOf(json.RawMessage{}))
+		Expect(json.Marshal(c)).To(MatchJSON(`{"id":"1","name":"foo","pid":42,"rucksack":{"motto":"spam"}}`))
+	})
+
+	It("unmarshals a container's Rucksack into an existing Rucksack", func() {
+		c := Container{Rucksack: &rucksack{}}
+		Expect(json.Unmarshal([]byte(`{"id":"1","name":"foo","pid":42,"rucksack":{"motto":"spam"}}`), &c)).
+			To(Succeed())
+		Expect(c.Rucksack).To(Equal(&rucksack{Motto: "spam"}))
+
+		c = Container{Rucksack: &rucksack{}}
+		Expect(json.Unmarshal([]byte(`{"id":"1","name":"foo","pid":42,"rucksack":42}`), &c)).
+			To(MatchError(ContainSubstring(`cannot unmarshal Rucksack of container "foo"`)))
+
+		Expect(json.Unmarshal([]byte(`{"id":42}`), &c)).NotTo(Succeed())
+	})
+
+	It("round-trips a container's start time", func() {
+		c := Container{ID: "1", Name: "foo", PID: 42,
+			StartedAt: time.Date(2026, 10, 16, 12, 34, 56, 0, time.UTC)}
+		j := Successful(json.Marshal(c))
+		Expect(j).To(MatchJSON(`{"id":"1","name":"foo","pid":42,"started":"2026-10-16T12:34:56Z"}`))
+		var c2 Container
+		Expect(json.Unmarshal(j, &c2)).To(Succeed())
+		Expect(c2.StartedAt.Equal(c.StartedAt)).To(BeTrue())
+	})
+
+	It("round-trips a container's health status", func() {
+		c := Container{ID: "1", Name: "foo", PID: 42, Health: HealthUnhealthy}
+		j := Successful(json.Marshal(c))
+		Expect(j).To(MatchJSON(`{"id":"1","name":"foo","pid":42,"health":"unhealthy"}`))
+		var c2 Container
+		Expect(json.Unmarshal(j, &c2)).To(Succeed())
+		Expect(c2.Health).To(Equal(HealthUnhealthy))
+	})
+
+	It("round-trips a portfolio", func() {
+		j := Successful(json.Marshal(pf))
+		Expect(j).To(MatchJSON(`{"projects": [
+			{"name": "", "containers": [
+				{"id": "2", "name": "murky_moby", "pid": 123},
+				{"id": "3", "name": "pompous_paperboard", "pid": 666}
+			]},
+			{"name": "grumpy", "containers": [
+				{"id": "1", "name": "furious_furuncle", "labels": {"foo": "bar"}, "pid": 42,
+				 "project": "grumpy", "paused": true, "rucksack": {"motto": "I'm not dead yet"}}
+			]}
+		]}`))
+
+		var pf2 Portfolio
+		Expect(json.Unmarshal(j, &pf2)).To(Succeed())
+		Expect(pf2.Generation()).To(BeNumerically(">", pf.Generation()))
+		Expect(pf2.Names()).To(ConsistOf("grumpy"))
+		Expect(pf2.ContainerTotal()).To(Equal(3))
+		Expect(pf2.Container("furious_furuncle")).To(And(
+			HaveField("PID", 42),
+			HaveField("Paused", true),
+			HaveField("Labels", HaveKeyWithValue("foo", "bar"))))
+		Expect(json.Marshal(&pf2)).To(MatchJSON(j))
+	})
+
+	It("detaches the previous projects when unmarshalling into a portfolio", func() {
+		grumpy := pf.Project("grumpy")
+		zero := pf.Project("")
+		gen := pf.Generation()
+		Expect(json.Unmarshal([]byte(`{"projects": [
+			{"name": "grumpy", "containers": [
+				{"id": "1", "name": "furious_furuncle", "pid": 42, "project": "grumpy"}
+			]}
+		]}`), pf)).To(Succeed())
+		Expect(pf.Generation()).To(BeNumerically(">", gen))
+		gen = pf.Generation()
+
+		// Changing the previous projects must leave the portfolio alone.
+		Expect(grumpy.SetPaused("furious_furuncle", false)).To(HaveField("Paused", false))
+		Expect(zero.SetHealth("murky_moby", HealthHealthy)).To(HaveField("Health", HealthHealthy))
+		Expect(pf.Generation()).To(Equal(gen))
+		Expect(pf.Container("murky_moby")).To(BeNil())
+		Expect(pf.Container("furious_furuncle")).To(HaveField("Paused", false))
+		Expect(pf.ContainerByPID(123)).To(BeNil())
+		Expect(pf.SetPaused("furious_furuncle", "grumpy", true)).To(HaveField("Paused", true))
+		Expect(pf.Container("furious_furuncle")).To(HaveField("Paused", true))
+		Expect(pf.ContainerByPID(42)).To(HaveField("Paused", true))
+
+		// Detached projects are standalone projects.
+		Expect(json.Unmarshal([]byte(`{"name":"", "containers":[]}`), zero)).To(Succeed())
+	})
+
+	It("unpacks Rucksacks", func() {
+		j := Successful(json.Marshal(pf))
+		pf2 := Successful(UnmarshalPortfolio(j, RucksackUnpackerFunc(
+			func(container *Container, data json.RawMessage) error {
+				var r rucksack
+				if err := json.Unmarshal(data, &r); err != nil {
+					return err
+				}
+				container.Rucksack = &r
+				return nil
+			})))
+		Expect(pf2.Container("furious_furuncle").Rucksack).To(Equal(&rucksack{Motto: "I'm not dead yet"}))
+		Expect(pf2.Container("murky_moby").Rucksack).To(BeNil())
+
+		Expect(UnmarshalPortfolio(j, RucksackUnpackerFunc(
+			func(*Container, json.RawMessage) error { return errors.New("D'OH!") }))).Error().
+			To(MatchError(ContainSubstring(`cannot unpack Rucksack of container "furious_furuncle": D'OH!`)))
+	})
+
+	It("rejects inconsistent portfolios", func() {
+		Expect(UnmarshalPortfolio([]byte(`{"projects":[{"name":"grumpy","containers":[{"id":"1","name":"foo"}]}]}`), nil)).
+			Error().To(MatchError(`container "foo" of project "" listed in project "grumpy"`))
+		Expect(UnmarshalPortfolio([]byte(`{"projects":[{"name":"","containers":[null]}]}`), nil)).
+			Error().To(MatchError(`invalid null container in project ""`))
+		Expect(UnmarshalPortfolio([]byte(`{"projects":42}`), nil)).Error().To(HaveOccurred())
+	})
+
+	It("round-trips a standalone composer project", func() {
+		j := Successful(json.Marshal(pf.Project("")))
+		Expect(j).To(MatchJSON(`{"name": "", "containers": [
+			{"id": "2", "name": "murky_moby", "pid": 123},
+			{"id": "3", "name": "pompous_paperboard", "pid": 666}
+		]}`))
+
+		p := newComposerProject(nil, "gnampf")
+		Expect(json.Unmarshal(j, p)).To(Succeed())
+		Expect(p.Name).To(BeEmpty())
+		Expect(p.ContainerNames()).To(ConsistOf("murky_moby", "pompous_paperboard"))
+
+		Expect(json.Unmarshal(j, pf.Project(""))).To(MatchError(ContainSubstring("part of a portfolio")))
+		Expect(json.Unmarshal([]byte(`{"name":"","containers":[{"id":"1","name":"foo","project":"bar"}]}`), p)).
+			NotTo(Succeed())
+		Expect(json.Unmarshal([]byte(`{"name":42}`), p)).NotTo(Succeed())
+	})
+
+})
@@ -0,0 +1,114 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// NamespaceType identifies a type of Linux namespace, using the same names as
+// the namespace links in "/proc/PID/ns/"; see also namespaces(7).
+type NamespaceType string
+
+// The Linux namespace types.
+const (
+	CgroupNamespace  NamespaceType = "cgroup"
+	IPCNamespace     NamespaceType = "ipc"
+	MountNamespace   NamespaceType = "mnt"
+	NetworkNamespace NamespaceType = "net"
+	PIDNamespace     NamespaceType = "pid"
+	TimeNamespace    NamespaceType = "time"
+	UserNamespace    NamespaceType = "user"
+	UTSNamespace     NamespaceType = "uts"
+)
+
+// NamespaceTypes lists all Linux namespace types.
+var NamespaceTypes = []NamespaceType{
+	CgroupNamespace,
+	IPCNamespace,
+	MountNamespace,
+	NetworkNamespace,
+	PIDNamespace,
+	TimeNamespace,
+	UserNamespace,
+	UTSNamespace,
+}
+
+// Namespaces maps the types of Linux namespaces to the inode numbers of the
+// particular namespaces a container's initial process is attached to.
+// Namespace types not supported by the kernel are missing.
+type Namespaces map[NamespaceType]uint64
+
+// ReadNamespaces returns the inode numbers of the Linux namespaces the process
+// with the specified PID is attached to, using the proc filesystem mounted at
+// the specified root, such as "/proc". The PID must be valid in the PID
+// namespace of the proc filesystem.
+//
+// Reading namespaces only needs to stat the namespace links in
+// "/proc/PID/ns/", without the need to switch into namespaces.
+func ReadNamespaces(procfs string, pid int) (Namespaces, error) {
+	nsdir := filepath.Join(procfs, strconv.Itoa(pid), "ns")
+	var stat unix.Stat_t
+	if err := unix.Stat(nsdir, &stat); err != nil {
+		return nil, fmt.Errorf("cannot read namespaces of process with PID %d: %w", pid, err)
+	}
+	namespaces := Namespaces{}
+	for _, nstype := range NamespaceTypes {
+		// Kernels not supporting a particular namespace type lack the
+		// corresponding namespace link, so we simply skip it.
+		if err := unix.Stat(filepath.Join(nsdir, string(nstype)), &stat); err != nil {
+			continue
+		}
+		namespaces[nstype] = stat.Ino
+	}
+	return namespaces, nil
+}
+
+// nsKey identifies a particular Linux namespace by its type and inode number.
+type nsKey struct {
+	nstype NamespaceType
+	ino    uint64
+}
+
+// ContainersInNamespace returns the containers attached to the Linux
+// namespace of the specified type and with the specified inode number, such
+// as all containers sharing the same network namespace in a Kubernetes pod.
+// Only containers with known [Container.Namespaces] are taken into account.
+func (pf *Portfolio) ContainersInNamespace(nstype NamespaceType, ino uint64) []*Container {
+	pf.m.RLock()
+	defer pf.m.RUnlock()
+	return slices.Clone(pf.namespaces[nsKey{nstype: nstype, ino: ino}])
+}
+
+// indexNamespaces adds the namespaces of the specified container to the
+// portfolio's namespace index. The caller must hold the portfolio's lock.
+func (pf *Portfolio) indexNamespaces(cntr *Container) {
+	for nstype, ino := range cntr.Namespaces {
+		pf.namespaces.add(nsKey{nstype: nstype, ino: ino}, cntr)
+	}
+}
+
+// unindexNamespaces removes the namespaces of the specified container from the
+// portfolio's namespace index. The caller must hold the portfolio's lock.
+func (pf *Portfolio) unindexNamespaces(cntr *Container) {
+	for nstype, ino := range cntr.Namespaces {
+		pf.namespaces.remove(nsKey{nstype: nstype, ino: ino}, cntr)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"os"
+
+	"golang.org/x/sys/unix"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("namespaces", func() {
+
+	It("reads the namespaces of a process", func() {
+		var stat unix.Stat_t
+		Expect(unix.Stat("/proc/self/ns/net", &stat)).To(Succeed())
+		namespaces := Successful(ReadNamespaces("/proc", os.Getpid()))
+		Expect(namespaces).To(HaveKeyWithValue(NetworkNamespace, stat.Ino))
+		Expect(namespaces).To(HaveKey(MountNamespace))
+
+		Expect(ReadNamespaces("/proc", 0)).Error().To(MatchError(
+			ContainSubstring("cannot read namespaces of process with PID 0")))
+	})
+
+	It("finds containers by namespace", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle",
+			Namespaces: Namespaces{NetworkNamespace: 42, PIDNamespace: 1}})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", Project: "grumpy",
+			Namespaces: Namespaces{NetworkNamespace: 42, PIDNamespace: 2}})
+		pf.Add(&Container{ID: "3", Name: "pompous_paperboard"})
+
+		Expect(pf.ContainersInNamespace(NetworkNamespace, 42)).To(ConsistOf(
+			HaveField("Name", "furious_furuncle"),
+			HaveField("Name", "murky_moby")))
+		Expect(pf.ContainersInNamespace(PIDNamespace, 2)).To(ConsistOf(
+			HaveField("Name", "murky_moby")))
+		Expect(pf.ContainersInNamespace(PIDNamespace, 42)).To(BeEmpty())
+
+		pf.Project("grumpy").SetPaused("murky_moby", true)
+		Expect(pf.ContainersInNamespace(PIDNamespace, 2)).To(ConsistOf(
+			HaveField("Paused", true)))
+
+		pf.Remove("furious_furuncle", "")
+		Expect(pf.ContainersInNamespace(NetworkNamespace, 42)).To(ConsistOf(
+			HaveField("Name", "murky_moby")))
+	})
+
+	It("round-trips namespaces via JSON", func() {
+		c := &Container{ID: "1", Name: "furious_furuncle", Namespaces: Namespaces{NetworkNamespace: 42}}
+		j := Successful(json.Marshal(c))
+		Expect(j).To(MatchJSON(`{"id":"1","name":"furious_furuncle","pid":0,"namespaces":{"net":42}}`))
+
+		var pf Portfolio
+		Expect(json.Unmarshal([]byte(`{"projects":[{"name":"","containers":[`+string(j)+`]}]}`), &pf)).
+			To(Succeed())
+		Expect(pf.ContainersInNamespace(NetworkNamespace, 42)).To(ConsistOf(
+			HaveField("Name", "furious_furuncle")))
+	})
+
+})
@@ -0,0 +1,194 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"slices"
+)
+
+// Observer gets notified about changes to the containers and composer projects
+// (groups) of a [Portfolio] it has been registered with using
+// [Portfolio.Observe].
+//
+// Observers are notified after a change has been applied to the portfolio and
+// outside the portfolio's lock, so they can freely query the portfolio.
+// Notifications are delivered one at a time and in the order of the changes,
+// but observers must not mutate the portfolio they observe, as this would
+// deadlock.
+type Observer interface {
+	// ContainerAdded gets called after the specified container has been added
+	// to the portfolio.
+	ContainerAdded(cntr *Container)
+	// ContainerRemoved gets called after the specified container has been
+	// removed from the portfolio.
+	ContainerRemoved(cntr *Container)
+	// ContainerPauseChanged gets called after the paused state of a container
+	// has changed, passing the container in its new state.
+	ContainerPauseChanged(cntr *Container)
+	// ContainerHealthChanged gets called after the health status of a
+	// container has changed, passing the container in its new state.
+	ContainerHealthChanged(cntr *Container)
+	// ProjectAdded gets called when a composer project (group) of the
+	// specified name appears in the portfolio, before notifying about the
+	// container that caused it to appear. It never gets called for the "zero"
+	// project, as this always exists.
+	ProjectAdded(name string)
+	// ProjectRemoved gets called when the composer project (group) of the
+	// specified name disappears from the portfolio, after notifying about the
+	// removal of its last container. It never gets called for the "zero"
+	// project.
+	ProjectRemoved(name string)
+}
+
+// ObserverFuncs is an [Observer] calling only those of its notification
+// functions that are non-nil, so users need to supply only the notification
+// functions they are interested in.
+type ObserverFuncs struct {
+	OnContainerAdded         func(cntr *Container)
+	OnContainerRemoved       func(cntr *Container)
+	OnContainerPauseChanged  func(cntr *Container)
+	OnContainerHealthChanged func(cntr *Container)
+	OnProjectAdded           func(name string)
+	OnProjectRemoved         func(name string)
+}
+
+var _ Observer = ObserverFuncs{}
+
+// ContainerAdded calls OnContainerAdded, if set.
+func (o ObserverFuncs) ContainerAdded(cntr *Container) {
+	if o.OnContainerAdded != nil {
+		o.OnContainerAdded(cntr)
+	}
+}
+
+// ContainerRemoved calls OnContainerRemoved, if set.
+func (o ObserverFuncs) ContainerRemoved(cntr *Container) {
+	if o.OnContainerRemoved != nil {
+		o.OnContainerRemoved(cntr)
+	}
+}
+
+// ContainerPauseChanged calls OnContainerPauseChanged, if set.
+func (o ObserverFuncs) ContainerPauseChanged(cntr *Container) {
+	if o.OnContainerPauseChanged != nil {
+		o.OnContainerPauseChanged(cntr)
+	}
+}
+
+// ContainerHealthChanged calls OnContainerHealthChanged, if set.
+func (o ObserverFuncs) ContainerHealthChanged(cntr *Container) {
+	if o.OnContainerHealthChanged != nil {
+		o.OnContainerHealthChanged(cntr)
+	}
+}
+
+// ProjectAdded calls OnProjectAdded, if set.
+func (o ObserverFuncs) ProjectAdded(name string) {
+	if o.OnProjectAdded != nil {
+		o.OnProjectAdded(name)
+	}
+}
+
+// ProjectRemoved calls OnProjectRemoved, if set.
+func (o ObserverFuncs) ProjectRemoved(name string) {
+	if o.OnProjectRemoved != nil {
+		o.OnProjectRemoved(name)
+	}
+}
+
+// notification notifies an observer about a particular change.
+type notification func(Observer)
+
+// observation is a registered observer; we need our own unique registration
+// object as observers might not be comparable.
+type observation struct {
+	observer Observer
+}
+
+// Observe registers the specified observer to get notified about changes to
+// this portfolio, returning a function to unregister the observer again. The
+// observer only gets notified about future changes; use [Portfolio.Snapshot]
+// to learn about the current state of the portfolio.
+//
+// Please note that a watcher replaces its portfolio with a new one when it
+// resynchronizes with its container engine; observers registered with the old
+// portfolio won't be notified about changes to the new portfolio.
+func (pf *Portfolio) Observe(observer Observer) (unobserve func()) {
+	obs := &observation{observer: observer}
+	pf.m.Lock()
+	defer pf.m.Unlock()
+	// Observer lists are copy-on-write, so we can later deliver notifications
+	// without holding the portfolio's lock.
+	pf.observers = append(slices.Clone(pf.observers), obs)
+	return func() {
+		pf.m.Lock()
+		defer pf.m.Unlock()
+		pf.observers = slices.DeleteFunc(slices.Clone(pf.observers),
+			func(o *observation) bool { return o == obs })
+	}
+}
+
+// delivery is a batch of notifications pending delivery to the observers
+// registered at the time of the change.
+type delivery struct {
+	observers     []*observation
+	notifications []notification
+}
+
+// unlockAndNotify queues the notifications about the specified changes,
+// releases the portfolio's (write) lock, and then delivers all queued
+// notifications. In order to deliver notifications in the order of changes,
+// notifications are queued while still holding the portfolio lock, and the
+// queue is drained only while holding the notification lock. As the portfolio
+// lock isn't held while waiting for or holding the notification lock,
+// observers can freely query the portfolio even while other go routines are
+// changing it. The caller must hold the portfolio's lock.
+func (pf *Portfolio) unlockAndNotify(notifications ...notification) {
+	observers := pf.observers
+	if len(observers) == 0 || len(notifications) == 0 {
+		pf.m.Unlock()
+		return
+	}
+	pf.deliveries = append(pf.deliveries, delivery{
+		observers:     observers,
+		notifications: notifications,
+	})
+	pf.m.Unlock()
+	pf.deliver()
+}
+
+// deliver all queued notifications to their observers, in the order of the
+// changes. When deliver returns, all notifications queued before calling
+// deliver have been delivered, either by this or another go routine.
+func (pf *Portfolio) deliver() {
+	pf.notifym.Lock()
+	defer pf.notifym.Unlock()
+	for {
+		pf.m.Lock()
+		if len(pf.deliveries) == 0 {
+			pf.m.Unlock()
+			return
+		}
+		d := pf.deliveries[0]
+		pf.deliveries[0] = delivery{} // ...don't block gc
+		pf.deliveries = pf.deliveries[1:]
+		pf.m.Unlock()
+		for _, notify := range d.notifications {
+			for _, obs := range d.observers {
+				notify(obs.observer)
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// recorder is an Observer recording the notifications it receives in textual
+// form.
+type recorder struct {
+	m     sync.Mutex
+	notes []string
+}
+
+func (r *recorder) record(format string, args ...any) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.notes = append(r.notes, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Notes() []string {
+	r.m.Lock()
+	defer r.m.Unlock()
+	return r.notes
+}
+
+func (r *recorder) ContainerAdded(cntr *Container)   { r.record("+%s", cntr.Name) }
+func (r *recorder) ContainerRemoved(cntr *Container) { r.record("-%s", cntr.Name) }
+func (r *recorder) ContainerPauseChanged(cntr *Container) {
+	r.record("%s paused=%t", cntr.Name, cntr.Paused)
+}
+func (r *recorder) ContainerHealthChanged(cntr *Container) {
+	r.record("%s health=%s", cntr.Name, cntr.Health)
+}
+func (r *recorder) ProjectAdded(name string)   { r.record("+project %s", name) }
+func (r *recorder) ProjectRemoved(name string) { r.record("-project %s", name) }
+
+var _ = Describe("portfolio observers", func() {
+
+	It("notifies about containers and projects coming and going", func() {
+		pf := NewPortfolio()
+		r := &recorder{}
+		unobserve := pf.Observe(r)
+
+		Expect(pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})).To(BeTrue())
+		Expect(pf.Add(&Container{ID: "2", Name: "murky_moby", Project: "grumpy"})).To(BeTrue())
+		Expect(pf.Add(&Container{ID: "3", Name: "lonely_lumpy"})).To(BeTrue())
+		Expect(pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})).To(BeFalse())
+		Expect(pf.SetPaused("murky_moby", "grumpy", true)).NotTo(BeNil())
+		Expect(pf.SetPaused("murky_moby", "grumpy", true)).NotTo(BeNil())
+		Expect(pf.Project("").SetPaused("lonely_lumpy", true)).NotTo(BeNil())
+		Expect(pf.SetPaused("missing_moby", "grumpy", true)).To(BeNil())
+		Expect(pf.SetHealth("murky_moby", "grumpy", HealthHealthy)).To(HaveField("Health", HealthHealthy))
+		Expect(pf.SetHealth("murky_moby", "grumpy", HealthHealthy)).NotTo(BeNil())
+		Expect(pf.Project("").SetHealth("lonely_lumpy", HealthUnhealthy)).NotTo(BeNil())
+		Expect(pf.SetHealth("missing_moby", "grumpy", HealthHealthy)).To(BeNil())
+		Expect(pf.Remove("furious_furuncle", "grumpy")).NotTo(BeNil())
+		Expect(pf.Remove("murky_moby", "grumpy")).NotTo(BeNil())
+		Expect(pf.Remove("lonely_lumpy", "")).NotTo(BeNil())
+		Expect(pf.Remove("missing_moby", "")).To(BeNil())
+
+		Expect(r.Notes()).To(HaveExactElements(
+			"+project grumpy",
+			"+furious_furuncle",
+			"+murky_moby",
+			"+lonely_lumpy",
+			"murky_moby paused=true",
+			"lonely_lumpy paused=true",
+			"murky_moby health=healthy",
+			"lonely_lumpy health=unhealthy",
+			"-furious_furuncle",
+			"-murky_moby",
+			"-project grumpy",
+			"-lonely_lumpy",
+		))
+
+		unobserve()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		Expect(r.Notes()).To(HaveLen(12))
+	})
+
+	It("supports observer functions", func() {
+		pf := NewPortfolio()
+		var added []string
+		pf.Observe(ObserverFuncs{
+			OnContainerAdded: func(cntr *Container) { added = append(added, cntr.Name) },
+		})
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		pf.SetPaused("furious_furuncle", "grumpy", true)
+		pf.Remove("furious_furuncle", "grumpy")
+		Expect(added).To(ConsistOf("furious_furuncle"))
+	})
+
+	It("allows observers to query the portfolio", func() {
+		pf := NewPortfolio()
+		var names []string
+		pf.Observe(ObserverFuncs{
+			OnContainerAdded: func(cntr *Container) {
+				names = append(names, pf.Container(cntr.ID).Name)
+			},
+		})
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle"})
+		Expect(names).To(ConsistOf("furious_furuncle"))
+	})
+
+	It("notifies about unmarshalling", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		r := &recorder{}
+		pf.Observe(r)
+		Expect(json.Unmarshal([]byte(`{"projects":[{"name":"sleepy","containers":[{"id":"2","name":"murky_moby","project":"sleepy"}]}]}`),
+			pf)).To(Succeed())
+		Expect(r.Notes()).To(HaveExactElements(
+			"-furious_furuncle",
+			"-project grumpy",
+			"+project sleepy",
+			"+murky_moby",
+		))
+	})
+
+	It("delivers notifications in order of changes", func() {
+		pf := NewPortfolio()
+		r := &recorder{}
+		pf.Observe(r)
+		var wg sync.WaitGroup
+		for idx := range 10 {
+			wg.Go(func() {
+				name := fmt.Sprintf("moby_%d", idx)
+				pf.Add(&Container{ID: name, Name: name, Project: "grumpy"})
+				pf.Remove(name, "grumpy")
+			})
+		}
+		wg.Wait()
+		added := map[string]bool{}
+		projects := 0
+		for _, note := range r.Notes() {
+			switch note[0] {
+			case '+':
+				if note == "+project grumpy" {
+					projects++
+					Expect(projects).To(Equal(1))
+					continue
+				}
+				added[note[1:]] = true
+			case '-':
+				if note == "-project grumpy" {
+					projects--
+					Expect(projects).To(Equal(0))
+					continue
+				}
+				Expect(added).To(HaveKey(note[1:]))
+			}
+		}
+		Expect(projects).To(BeZero())
+	})
+
+	It("lets observers query the portfolio while it is concurrently changed", func(ctx SpecContext) {
+		pf := NewPortfolio()
+		var queries atomic.Int64
+		query := func(cntr *Container) {
+			time.Sleep(10 * time.Microsecond) // ...let other go routines change the portfolio.
+			_ = pf.Snapshot()
+			_ = pf.Container(cntr.ID)
+			_ = pf.ContainerTotal()
+			queries.Add(1)
+		}
+		pf.Observe(ObserverFuncs{
+			OnContainerAdded:   query,
+			OnContainerRemoved: query,
+		})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			var wg sync.WaitGroup
+			for idx := range 8 {
+				wg.Go(func() {
+					for round := range 200 {
+						name := fmt.Sprintf("moby_%d_%d", idx, round)
+						pf.Add(&Container{ID: name, Name: name, Project: "grumpy"})
+						pf.Remove(name, "grumpy")
+					}
+				})
+			}
+			wg.Wait()
+		}()
+		Eventually(ctx, done).Within(10 * time.Second).Should(BeClosed())
+		Expect(queries.Load()).To(Equal(int64(8 * 200 * 2)))
+		Expect(pf.ContainerTotal()).To(BeZero())
+	})
+
+})
@@ -0,0 +1,239 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// maxOwnerCacheEntries limits the number of processes an [OwnerFinder]
+// remembers; when exceeded, the OwnerFinder starts over with an empty cache.
+const maxOwnerCacheEntries = 16384
+
+// OwnerFinder finds the containers owning arbitrary processes, not just the
+// initial ("ealdorman") container processes tracked in a [Portfolio].
+//
+// For a given PID, an OwnerFinder walks up the process's ancestry via the proc
+// filesystem until it hits a PID known to the Portfolio. This covers all
+// processes that are (grand) children of a container's initial process.
+// Processes that have been started separately inside a container, such as via
+// "docker exec", are children of the container engine's shim process instead.
+// For them, the OwnerFinder falls back to comparing the process's cgroup
+// membership with the cgroup memberships of the initial container processes.
+//
+// An OwnerFinder caches the results of ancestry walks, detecting reused PIDs
+// based on process start times. The cache is independent of specific
+// portfolios, so the same OwnerFinder can be used with different portfolios,
+// such as the ones returned by a watcher over time.
+//
+// An OwnerFinder is safe for concurrent use.
+type OwnerFinder struct {
+	procfs  string              // root of the proc filesystem to use.
+	owners  map[int]owner       // cached owners of processes.
+	cgroups map[int]cgroupOwner // cached cgroup memberships of initial container processes.
+	m       sync.Mutex
+}
+
+// owner caches the owner of a specific process.
+type owner struct {
+	starttime  uint64 // start time of the process, to detect reused PIDs.
+	pid        int    // PID of the owning container's initial process, or zero.
+	generation uint64 // portfolio generation when there was no owner.
+}
+
+// cgroupOwner caches the cgroup membership of an initial container process.
+type cgroupOwner struct {
+	starttime uint64
+	cgroups   []byte // contents of the process's "cgroup" proc file.
+}
+
+// NewOwnerFinder returns a new OwnerFinder, using the proc filesystem at
+// "/proc", unless specified otherwise using options.
+func NewOwnerFinder(opts ...OwnerFinderOption) *OwnerFinder {
+	f := &OwnerFinder{
+		procfs:  "/proc",
+		owners:  map[int]owner{},
+		cgroups: map[int]cgroupOwner{},
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// OwnerFinderOption represents options to NewOwnerFinder when creating new
+// OwnerFinders.
+type OwnerFinderOption func(*OwnerFinder)
+
+// WithProcfs sets the root of the proc filesystem to use, such as when the
+// host's proc filesystem has been mounted at a different location inside a
+// container.
+func WithProcfs(root string) OwnerFinderOption {
+	return func(f *OwnerFinder) {
+		f.procfs = root
+	}
+}
+
+// Owner returns the container in the specified portfolio that owns the process
+// with the specified PID, or nil if there is no such container or the process
+// doesn't exist (anymore).
+func (f *OwnerFinder) Owner(pf *Portfolio, pid int) *Container {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if len(f.owners) > maxOwnerCacheEntries {
+		f.owners = map[int]owner{}
+	}
+	generation := pf.Generation()
+	// Walk up the ancestry of the process until we either hit a process
+	// known to belong to a container, or run out of ancestors.
+	var walked []int
+	var starttimes []uint64
+	ownerpid := 0
+	for p := pid; p > 0; {
+		starttime, ppid, err := f.stat(p)
+		if err != nil {
+			break
+		}
+		if cached, ok := f.owners[p]; ok && cached.starttime == starttime {
+			if cached.pid != 0 && pf.ContainerByPID(cached.pid) != nil {
+				ownerpid = cached.pid
+				break
+			}
+			if cached.pid == 0 && cached.generation == generation {
+				break
+			}
+		}
+		walked = append(walked, p)
+		starttimes = append(starttimes, starttime)
+		if pf.ContainerByPID(p) != nil {
+			ownerpid = p
+			break
+		}
+		p = ppid
+	}
+	if len(walked) == 0 {
+		return owned(pf, ownerpid)
+	}
+	for idx, p := range walked {
+		f.owners[p] = owner{
+			starttime:  starttimes[idx],
+			pid:        ownerpid,
+			generation: generation,
+		}
+	}
+	if ownerpid != 0 {
+		return owned(pf, ownerpid)
+	}
+	// The process's ancestry didn't lead to a container, so check the cgroup
+	// membership of only this particular process; its ancestors, such as a
+	// container engine shim, might well be shared between containers.
+	ownerpid = f.cgroupOwner(pf, pid)
+	f.owners[pid] = owner{
+		starttime:  starttimes[0],
+		pid:        ownerpid,
+		generation: generation,
+	}
+	return owned(pf, ownerpid)
+}
+
+// owned returns the container with the specified initial process PID, or nil
+// if the PID is zero.
+func owned(pf *Portfolio, pid int) *Container {
+	if pid == 0 {
+		return nil
+	}
+	return pf.ContainerByPID(pid)
+}
+
+// cgroupOwner returns the PID of the initial process of the container with the
+// same cgroup membership as the process with the specified PID, or zero if
+// there is no such container. Memberships matching multiple containers are
+// considered to be ambiguous, thus returning zero.
+func (f *OwnerFinder) cgroupOwner(pf *Portfolio, pid int) int {
+	cgroups, err := os.ReadFile(filepath.Join(f.procfs, strconv.Itoa(pid), "cgroup"))
+	if err != nil || len(cgroups) == 0 {
+		return 0
+	}
+	known := map[int]cgroupOwner{}
+	ownerpid := 0
+	for cntr := range pf.AllContainers() {
+		starttime, _, err := f.stat(cntr.PID)
+		if err != nil {
+			continue
+		}
+		cached, ok := f.cgroups[cntr.PID]
+		if !ok || cached.starttime != starttime {
+			cntrcgroups, err := os.ReadFile(filepath.Join(f.procfs, strconv.Itoa(cntr.PID), "cgroup"))
+			if err != nil {
+				continue
+			}
+			cached = cgroupOwner{starttime: starttime, cgroups: cntrcgroups}
+		}
+		known[cntr.PID] = cached
+		if !bytes.Equal(cached.cgroups, cgroups) {
+			continue
+		}
+		if ownerpid != 0 {
+			ownerpid = -1 // ambiguous
+			continue
+		}
+		ownerpid = cntr.PID
+	}
+	// Only keep the cgroup memberships of current containers.
+	f.cgroups = known
+	return max(ownerpid, 0)
+}
+
+// stat returns the start time and parent PID of the process with the
+// specified PID.
+func (f *OwnerFinder) stat(pid int) (starttime uint64, ppid int, err error) {
+	stat, err := os.ReadFile(filepath.Join(f.procfs, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseStat(stat)
+}
+
+// parseStat returns the start time and parent PID from the contents of a
+// process's "stat" proc file; see also proc_pid_stat(5). As the process name
+// (in parentheses) might contain spaces and parentheses, parsing starts after
+// the last closing parenthesis.
+func parseStat(stat []byte) (starttime uint64, ppid int, err error) {
+	idx := bytes.LastIndexByte(stat, ')')
+	if idx < 0 {
+		return 0, 0, errors.New("malformed process stat")
+	}
+	// Fields following the process name, starting with the process state as
+	// field 3 (when counting from 1).
+	fields := bytes.Fields(stat[idx+1:])
+	if len(fields) < 20 {
+		return 0, 0, errors.New("malformed process stat")
+	}
+	ppid, err = strconv.Atoi(string(fields[4-3]))
+	if err != nil {
+		return 0, 0, err
+	}
+	starttime, err = strconv.ParseUint(string(fields[22-3]), 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return starttime, ppid, nil
+}
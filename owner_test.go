@@ -0,0 +1,115 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeProcess creates the "stat" and optionally "cgroup" proc files for a
+// fake process in the specified fake proc filesystem.
+func fakeProcess(procfs string, pid int, comm string, ppid int, starttime uint64, cgroup string) {
+	GinkgoHelper()
+	dir := filepath.Join(procfs, strconv.Itoa(pid))
+	Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+	stat := fmt.Sprintf("%d (%s) S %d %s %d 0 0\n",
+		pid, comm, ppid, strings.Repeat("0 ", 17), starttime)
+	Expect(os.WriteFile(filepath.Join(dir, "stat"), []byte(stat), 0o644)).To(Succeed())
+	if cgroup != "" {
+		Expect(os.WriteFile(filepath.Join(dir, "cgroup"), []byte(cgroup), 0o644)).To(Succeed())
+	}
+}
+
+var _ = Describe("finding owners of processes", func() {
+
+	It("parses process stat", func() {
+		starttime, ppid, err := parseStat([]byte("42 (foo) bar) S 1 " + strings.Repeat("0 ", 17) + "12345 0 0"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ppid).To(Equal(1))
+		Expect(starttime).To(Equal(uint64(12345)))
+
+		_, _, err = parseStat([]byte("42 (foo S 1"))
+		Expect(err).To(HaveOccurred())
+		_, _, err = parseStat([]byte("42 (foo) S 1 0"))
+		Expect(err).To(HaveOccurred())
+		_, _, err = parseStat([]byte("42 (foo) S x " + strings.Repeat("0 ", 17) + "12345 0 0"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("finds the owner of the current process", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", PID: os.Getppid()})
+		f := NewOwnerFinder()
+		Expect(f.Owner(pf, os.Getpid())).To(HaveField("Name", "furious_furuncle"))
+		Expect(f.Owner(pf, os.Getppid())).To(HaveField("Name", "furious_furuncle"))
+		Expect(f.Owner(pf, 0)).To(BeNil())
+	})
+
+	It("finds owners via ancestry and cgroups, caching results", func() {
+		procfs := GinkgoT().TempDir()
+		fakeProcess(procfs, 1, "init", 0, 1, "0::/init.scope\n")
+		fakeProcess(procfs, 100, "containerd-shim", 1, 100, "0::/system.slice/containerd.service\n")
+		fakeProcess(procfs, 101, "sleep", 100, 101, "0::/system.slice/docker-1.scope\n")
+		fakeProcess(procfs, 102, "sh", 101, 102, "0::/system.slice/docker-1.scope\n")
+		fakeProcess(procfs, 103, "sleep (deep)", 102, 103, "0::/system.slice/docker-1.scope\n")
+		fakeProcess(procfs, 104, "exec'd", 100, 104, "0::/system.slice/docker-1.scope\n")
+		fakeProcess(procfs, 200, "bash", 1, 200, "0::/user.slice\n")
+
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", PID: 101})
+		f := NewOwnerFinder(WithProcfs(procfs))
+
+		Expect(f.Owner(pf, 103)).To(HaveField("Name", "furious_furuncle"))
+		Expect(f.owners[102].pid).To(Equal(101))
+		Expect(f.Owner(pf, 102)).To(HaveField("Name", "furious_furuncle"))
+		Expect(f.Owner(pf, 104)).To(HaveField("Name", "furious_furuncle"))
+		Expect(f.owners).To(HaveKey(100))
+		Expect(f.owners[100].pid).To(BeZero())
+		Expect(f.Owner(pf, 200)).To(BeNil())
+		Expect(f.Owner(pf, 666)).To(BeNil())
+
+		By("detecting reused PIDs")
+		fakeProcess(procfs, 102, "sh", 1, 1000, "0::/user.slice\n")
+		Expect(f.Owner(pf, 102)).To(BeNil())
+
+		By("noticing portfolio changes")
+		pf.Add(&Container{ID: "2", Name: "murky_moby", PID: 200})
+		Expect(f.Owner(pf, 200)).To(HaveField("Name", "murky_moby"))
+		pf.Remove("furious_furuncle", "")
+		Expect(f.Owner(pf, 103)).To(BeNil())
+	})
+
+	It("doesn't attribute ambiguous cgroup memberships", func() {
+		procfs := GinkgoT().TempDir()
+		fakeProcess(procfs, 100, "shim", 1, 100, "0::/kubepods/pod1\n")
+		fakeProcess(procfs, 101, "pause", 100, 101, "0::/kubepods/pod1\n")
+		fakeProcess(procfs, 102, "sleep", 100, 102, "0::/kubepods/pod1\n")
+		fakeProcess(procfs, 103, "exec'd", 100, 103, "0::/kubepods/pod1\n")
+
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "pause", PID: 101})
+		pf.Add(&Container{ID: "2", Name: "sleepy", PID: 102})
+		f := NewOwnerFinder(WithProcfs(procfs))
+		Expect(f.Owner(pf, 103)).To(BeNil())
+	})
+
+})
@@ -16,30 +16,80 @@ package whalewatcher
 
 import (
 	"iter"
-	"maps"
 	"sync"
+	"sync/atomic"
 )
 
+// generations is the source of Portfolio generation numbers. It is shared by
+// all portfolios so that generation numbers never go backwards, even when a
+// watcher swaps in a fresh portfolio while resynchronizing with its container
+// engine.
+var generations atomic.Uint64
+
 // Portfolio represents all known composer projects, including the "zero"
 // (unnamed) project. The "zero" project has the zero/empty name and contains
 // all containers that don't belong to any named composer project. The Portfolio
 // manages projects implicitly when adding and removing containers belonging to
 // projects. Thus, there is no need to explicitly add or delete composer
 // projects.
+//
+// Each change to a Portfolio's containers, including changes to their paused
+// states, advances the Portfolio's generation number. Use [Portfolio.Snapshot]
+// to get a consistent, point-in-time view of a Portfolio together with its
+// generation.
+//
+// Portfolios index their containers by ID, name, PID, and (if known) Linux
+// namespaces, so that looking up individual containers doesn't depend on the
+// number of containers.
+//
+// By default, a Portfolio groups containers by their composer projects.
+// Portfolios created using the [WithGrouping] option instead group containers
+// using the specified [Grouping], such as by label. The groups then are
+// represented as ComposerProjects named after their groups.
+//
+// Changes to a Portfolio can be observed by registering an [Observer] using
+// [Portfolio.Observe], regardless of who changes the Portfolio.
 type Portfolio struct {
-	projects map[string]*ComposerProject
-	m        sync.RWMutex
+	projects   map[string]*ComposerProject // by group name.
+	grouping   Grouping                    // never changes after creation.
+	index      containerIndex              // all containers indexed by ID and name.
+	pids       multiIndex[int]             // all containers indexed by PID.
+	namespaces multiIndex[nsKey]           // all containers indexed by their namespaces.
+	generation uint64                      // current generation; only changed while holding m.
+	observers  []*observation              // copy-on-write list of observers.
+	m          sync.RWMutex
+	notifym    sync.Mutex // serializes notifying observers.
+	deliveries []delivery // queued notifications, guarded by m.
 }
 
-// NewPortfolio returns a new Portfolio.
-func NewPortfolio() *Portfolio {
+// NewPortfolio returns a new Portfolio, taking the specified options into
+// account.
+func NewPortfolio(opts ...PortfolioOption) *Portfolio {
 	pf := &Portfolio{
-		projects: make(map[string]*ComposerProject),
+		projects:   make(map[string]*ComposerProject),
+		index:      newContainerIndex(),
+		pids:       multiIndex[int]{},
+		namespaces: multiIndex[nsKey]{},
+		generation: generations.Add(1),
+	}
+	for _, opt := range opts {
+		opt(pf)
 	}
-	pf.projects[""] = newComposerProject("")
+	pf.projects[""] = newComposerProject(pf, "")
 	return pf
 }
 
+// Generation returns the current generation number of this portfolio.
+// Generation numbers increase monotonically with each change to a portfolio.
+// In addition, a newly created portfolio always starts with a generation
+// number that is higher than the generation numbers of any already existing
+// portfolios.
+func (pf *Portfolio) Generation() uint64 {
+	pf.m.RLock()
+	defer pf.m.RUnlock()
+	return pf.generation
+}
+
 // Names returns the names of all composer projects sans the "zero" project.
 func (pf *Portfolio) Names() []string {
 	pf.m.RLock()
@@ -62,23 +112,29 @@ func (pf *Portfolio) Project(name string) *ComposerProject {
 	return pf.projects[name]
 }
 
-// Container returns the [Container] with the specified name, regardless of
-// which project it is in. It returns nil, if no container with the specified
-// name could be found.
+// Container returns the [Container] with the specified ID or name, regardless
+// of which project it is in. IDs take precedence over names. It returns nil,
+// if no container with the specified ID or name could be found.
 func (pf *Portfolio) Container(nameorid string) *Container {
 	pf.m.RLock()
 	defer pf.m.RUnlock()
-	for _, project := range pf.projects {
-		if container := project.Container(nameorid); container != nil {
-			return container
-		}
-	}
-	return nil
+	return pf.index.lookup(nameorid)
+}
+
+// ContainerByPID returns the [Container] with the specified PID of its initial
+// container process, regardless of which project it is in. It returns nil, if
+// no container with the specified PID could be found.
+func (pf *Portfolio) ContainerByPID(pid int) *Container {
+	pf.m.RLock()
+	defer pf.m.RUnlock()
+	return pf.pids.first(pid)
 }
 
 // ContainerTotal returns the total number of containers over all projects,
 // including non-project "standalone" containers.
 func (pf *Portfolio) ContainerTotal() (total int) {
+	pf.m.RLock()
+	defer pf.m.RUnlock()
 	for _, project := range pf.projects {
 		total += len(project.Containers())
 	}
@@ -87,58 +143,175 @@ func (pf *Portfolio) ContainerTotal() (total int) {
 
 // AllContainers returns an iterator that iterates over the complete workload,
 // that is, over all containers in all projects (including the zero/unnamed
-// project).
+// project). The iterator works on a [Snapshot] of this portfolio taken at the
+// time of calling AllContainers, so later changes to the portfolio won't
+// become visible while iterating.
 func (pf *Portfolio) AllContainers() iter.Seq[*Container] {
-	pf.m.RLock()
-	defer pf.m.RUnlock()
-	projects := maps.Clone(pf.projects)
-	return func(yield func(*Container) bool) {
-		for _, project := range projects {
-			for _, container := range project.Containers() {
-				if !yield(container) {
-					return
-				}
-			}
-		}
-	}
+	return pf.Snapshot().AllContainers()
 }
 
-// Add a container to the portfolio, creating also its composer project if that
-// is not yet known. Returns true if the container was newly added, false if it
-// already exists.
+// Add a container to the portfolio, creating also its composer project (or
+// rather, group) if that is not yet known. Returns true if the container was
+// newly added, false if it already exists.
 func (pf *Portfolio) Add(cntr *Container) bool {
 	pf.m.Lock()
-	defer pf.m.Unlock()
 
 	// Do we have already the container's project in store or do we need to
 	// create it?
-	projname := cntr.ProjectName()
+	var notifications []notification
+	projname := pf.group(cntr)
 	proj, ok := pf.projects[projname]
 	if !ok {
-		proj = newComposerProject(projname)
+		proj = newComposerProject(pf, projname)
 		pf.projects[projname] = proj
+		notifications = append(notifications,
+			func(o Observer) { o.ProjectAdded(projname) })
+	}
+	// Let the project deal with the gory details of adding or not. As a newly
+	// created project is empty, adding always succeeds in this case.
+	if !proj.add(cntr) {
+		pf.m.Unlock()
+		return false
 	}
-	// Let the project deal with the gory details of adding or not.
-	return proj.add(cntr)
+	pf.indexContainer(cntr)
+	pf.generation = generations.Add(1)
+	pf.unlockAndNotify(append(notifications,
+		func(o Observer) { o.ContainerAdded(cntr) })...)
+	return true
 }
 
 // Remove a container identified by its ID or name as well as its composer
-// project name from the portfolio, removing its composer project if it was the
-// only container left in the project.
+// project name from the portfolio, removing its composer project (group) if it
+// was the only container left in the project. Please note that the project name
+// always is the container's [Container.Project], even if the portfolio uses a
+// different [Grouping].
 //
 // The information about the removed container is returned, otherwise if no such
 // container exists, nil is returned instead.
 func (pf *Portfolio) Remove(nameorid string, project string) (cntr *Container) {
 	pf.m.Lock()
-	defer pf.m.Unlock()
-
-	if proj, ok := pf.projects[project]; ok {
-		cntr = proj.remove(nameorid)
-		if project != "" && len(proj.Containers()) == 0 {
-			// The (non-zero) project has become empty, so we remove this
-			// project from the portfolio.
-			delete(pf.projects, project)
+
+	cntr = pf.lookup(nameorid, project)
+	if cntr == nil {
+		pf.m.Unlock()
+		return nil
+	}
+	group := pf.group(cntr)
+	proj := pf.projects[group]
+	proj.m.Lock()
+	proj.drop(cntr)
+	empty := len(proj.containers) == 0
+	proj.m.Unlock()
+	pf.unindexContainer(cntr)
+	pf.generation = generations.Add(1)
+	notifications := []notification{
+		func(o Observer) { o.ContainerRemoved(cntr) },
+	}
+	if group != "" && empty {
+		// The (non-zero) project has become empty, so we remove this
+		// project from the portfolio.
+		delete(pf.projects, group)
+		proj.detach()
+		notifications = append(notifications,
+			func(o Observer) { o.ProjectRemoved(group) })
+	}
+	pf.unlockAndNotify(notifications...)
+	return cntr
+}
+
+// SetPaused changes the Paused state of the container identified by its ID or
+// name as well as its composer project name, returning the container in its
+// new state. Similar to [Portfolio.Remove], the project name always is the
+// container's [Container.Project], even if the portfolio uses a different
+// [Grouping]. If there is no such container, nil is returned instead.
+func (pf *Portfolio) SetPaused(nameorid string, project string, paused bool) *Container {
+	pf.m.Lock()
+
+	cntr := pf.lookup(nameorid, project)
+	if cntr == nil {
+		pf.m.Unlock()
+		return nil
+	}
+	return pf.setPaused(pf.projects[pf.group(cntr)], cntr, paused)
+}
+
+// setPaused changes the Paused state of the specified container of the
+// specified project, returning the container in its new state and notifying
+// observers if the state changed. The caller must hold the portfolio's lock,
+// which setPaused releases.
+func (pf *Portfolio) setPaused(proj *ComposerProject, cntr *Container, paused bool) *Container {
+	updated := proj.setPaused(cntr, paused)
+	if updated == nil || updated == cntr {
+		pf.m.Unlock()
+		return updated
+	}
+	pf.unlockAndNotify(func(o Observer) { o.ContainerPauseChanged(updated) })
+	return updated
+}
+
+// SetHealth changes the Health status of the container identified by its ID
+// or name as well as its composer project name, returning the container in its
+// new state. The project name is handled the same as with
+// [Portfolio.SetPaused]. If there is no such container, nil is returned
+// instead.
+func (pf *Portfolio) SetHealth(nameorid string, project string, health string) *Container {
+	pf.m.Lock()
+
+	cntr := pf.lookup(nameorid, project)
+	if cntr == nil {
+		pf.m.Unlock()
+		return nil
+	}
+	return pf.setHealth(pf.projects[pf.group(cntr)], cntr, health)
+}
+
+// setHealth changes the Health status of the specified container of the
+// specified project, returning the container in its new state and notifying
+// observers if the status changed. The caller must hold the portfolio's lock,
+// which setHealth releases.
+func (pf *Portfolio) setHealth(proj *ComposerProject, cntr *Container, health string) *Container {
+	updated := proj.setHealth(cntr, health)
+	if updated == nil || updated == cntr {
+		pf.m.Unlock()
+		return updated
+	}
+	pf.unlockAndNotify(func(o Observer) { o.ContainerHealthChanged(updated) })
+	return updated
+}
+
+// lookup returns the container with the specified ID or, failing that, name,
+// and belonging to the specified composer project. It returns nil if there is
+// no such container. The caller must hold the portfolio's lock.
+func (pf *Portfolio) lookup(nameorid string, project string) *Container {
+	for _, cntrs := range [][]*Container{pf.index.ids[nameorid], pf.index.names[nameorid]} {
+		for _, cntr := range cntrs {
+			if cntr.Project == project {
+				return cntr
+			}
 		}
 	}
-	return
+	return nil
+}
+
+// replace the specified container in the portfolio's indices with a new
+// version of it. The caller must hold the portfolio's lock.
+func (pf *Portfolio) replace(old, updated *Container) {
+	pf.unindexContainer(old)
+	pf.indexContainer(updated)
+}
+
+// indexContainer adds the specified container to the portfolio's indices. The
+// caller must hold the portfolio's lock.
+func (pf *Portfolio) indexContainer(cntr *Container) {
+	pf.index.add(cntr)
+	pf.pids.add(cntr.PID, cntr)
+	pf.indexNamespaces(cntr)
+}
+
+// unindexContainer removes the specified container from the portfolio's
+// indices. The caller must hold the portfolio's lock.
+func (pf *Portfolio) unindexContainer(cntr *Container) {
+	pf.index.remove(cntr)
+	pf.pids.remove(cntr.PID, cntr)
+	pf.unindexNamespaces(cntr)
 }
@@ -102,6 +102,26 @@ var _ = Describe("composer project portfolio", func() {
 
 	})
 
+	It("finds containers by ID, name, and PID", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", PID: 42, Project: "grumpy"})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", PID: 666})
+		pf.Add(&Container{ID: "3", Name: "1", PID: 123})
+
+		Expect(pf.Container("1")).To(HaveField("Name", "furious_furuncle"))
+		Expect(pf.Container("murky_moby")).To(HaveField("ID", "2"))
+		Expect(pf.ContainerByPID(666)).To(HaveField("ID", "2"))
+		Expect(pf.ContainerByPID(1)).To(BeNil())
+
+		pf.Project("").SetPaused("2", true)
+		Expect(pf.Container("2")).To(HaveField("Paused", true))
+		Expect(pf.ContainerByPID(666)).To(HaveField("Paused", true))
+
+		pf.Remove("1", "grumpy")
+		Expect(pf.Container("1")).To(HaveField("ID", "3"))
+		Expect(pf.ContainerByPID(42)).To(BeNil())
+	})
+
 	It("iterates the complete workload", func() {
 		pf := NewPortfolio()
 		Expect(pf).NotTo(BeNil())
@@ -31,17 +31,24 @@ import (
 // in our context, as we just want to understand the concrete relationships
 // between projects and their containers.
 type ComposerProject struct {
-	Name       string       // composer project name, guaranteed to be constant.
-	containers []*Container // containers belonging to this project (unsorted).
+	Name       string             // composer project name, guaranteed to be constant.
+	containers []*Container       // containers belonging to this project (unsorted).
+	slots      map[*Container]int // positions of containers in the containers slice.
+	index      containerIndex     // containers indexed by ID and name.
+	portfolio  *Portfolio         // portfolio this project belongs to, if any.
 	m          sync.RWMutex
 }
 
 // newComposerProject returns a new composer project of the specified name and
-// without any containers yet.
-func newComposerProject(name string) *ComposerProject {
+// without any containers yet. The project is associated with the specified
+// portfolio, if any.
+func newComposerProject(pf *Portfolio, name string) *ComposerProject {
 	return &ComposerProject{
 		Name:       name,
 		containers: []*Container{},
+		slots:      map[*Container]int{},
+		index:      newContainerIndex(),
+		portfolio:  pf,
 	}
 }
 
@@ -72,38 +79,127 @@ func (p *ComposerProject) ContainerNames() []string {
 func (p *ComposerProject) Container(nameorid string) *Container {
 	p.m.RLock()
 	defer p.m.RUnlock()
-
-	for _, cntr := range p.containers {
-		if cntr.Name == nameorid || cntr.ID == nameorid {
-			return cntr
-		}
-	}
-	return nil
+	return p.index.lookup(nameorid)
 }
 
 // SetPaused changes a [Container]'s Paused state, obeying the design
 // restriction that Container objects are immutable. It returns the container in
 // its new state.
 func (p *ComposerProject) SetPaused(nameorid string, paused bool) *Container {
+	// When this project is part of a portfolio, we need to lock the portfolio
+	// first, so that portfolio snapshots are always consistent and the
+	// portfolio's generation correctly reflects the pause state change. Please
+	// note the lock order: first portfolio, then project.
+	pf := p.lockPortfolio()
+	p.m.RLock()
+	cntr := p.index.lookup(nameorid)
+	p.m.RUnlock()
+	// Please note that setPaused silently ignores a non-existing name/ID.
+	if pf == nil {
+		return p.setPaused(cntr, paused)
+	}
+	return pf.setPaused(p, cntr, paused)
+}
+
+// SetHealth changes a [Container]'s Health status, obeying the design
+// restriction that Container objects are immutable. It returns the container in
+// its new state.
+func (p *ComposerProject) SetHealth(nameorid string, health string) *Container {
+	// Same locking order as in SetPaused: first portfolio, then project.
+	pf := p.lockPortfolio()
+	p.m.RLock()
+	cntr := p.index.lookup(nameorid)
+	p.m.RUnlock()
+	if pf == nil {
+		return p.setHealth(cntr, health)
+	}
+	return pf.setHealth(p, cntr, health)
+}
+
+// lockPortfolio locks the portfolio this project belongs to and returns it. If
+// the project doesn't belong to a portfolio (anymore), it returns nil instead.
+func (p *ComposerProject) lockPortfolio() *Portfolio {
+	for {
+		p.m.RLock()
+		pf := p.portfolio
+		p.m.RUnlock()
+		if pf == nil {
+			return nil
+		}
+		pf.m.Lock()
+		// The project might have been detached from its portfolio while we
+		// were waiting for the portfolio's lock.
+		p.m.RLock()
+		attached := p.portfolio == pf
+		p.m.RUnlock()
+		if attached {
+			return pf
+		}
+		pf.m.Unlock()
+	}
+}
+
+// detach this project from its portfolio, turning it into a standalone
+// project. The caller must hold the portfolio's lock.
+func (p *ComposerProject) detach() {
 	p.m.Lock()
 	defer p.m.Unlock()
+	p.portfolio = nil
+}
 
-	for idx, cntr := range p.containers {
-		if cntr.Name == nameorid || cntr.ID == nameorid {
-			if paused != cntr.Paused {
-				// As Container is supposed to be immutable, we clone the existing
-				// object, then modify the copy, and finally update the container
-				// reference to point to the copy with the updated state.
-				c := *cntr
-				c.Paused = paused
-				p.containers[idx] = &c
-				return &c
-			}
-			return cntr
+// setPaused changes the Paused state of the specified container of this
+// project, returning the container in its new state, or nil if the container
+// isn't part of this project. If this project is part of a portfolio, the
+// caller must hold the portfolio's lock.
+func (p *ComposerProject) setPaused(cntr *Container, paused bool) *Container {
+	return p.update(cntr, func(c *Container) bool {
+		if c.Paused == paused {
+			return false
+		}
+		c.Paused = paused
+		return true
+	})
+}
+
+// setHealth changes the Health status of the specified container of this
+// project, returning the container in its new state, or nil if the container
+// isn't part of this project. If this project is part of a portfolio, the
+// caller must hold the portfolio's lock.
+func (p *ComposerProject) setHealth(cntr *Container, health string) *Container {
+	return p.update(cntr, func(c *Container) bool {
+		if c.Health == health {
+			return false
 		}
+		c.Health = health
+		return true
+	})
+}
+
+// update the specified container of this project using the specified modify
+// function, returning the container in its new state, or nil if the container
+// isn't part of this project. If modify reports no change, the original
+// container is returned. If this project is part of a portfolio, the caller
+// must hold the portfolio's lock.
+func (p *ComposerProject) update(cntr *Container, modify func(c *Container) bool) *Container {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if _, ok := p.slots[cntr]; !ok {
+		return nil
 	}
-	// Silently ignore a non-existing name/ID.
-	return nil
+	// As Container is supposed to be immutable, we clone the existing object,
+	// then modify the copy, and finally update the container reference to
+	// point to the copy with the updated state.
+	c := *cntr
+	if !modify(&c) {
+		return cntr
+	}
+	p.replace(cntr, &c)
+	if pf := p.portfolio; pf != nil {
+		pf.replace(cntr, &c)
+		pf.generation = generations.Add(1)
+	}
+	return &c
 }
 
 // String returns a textual representation of a composer project with its
@@ -130,12 +226,14 @@ func (p *ComposerProject) add(c *Container) bool {
 	p.m.Lock()
 	defer p.m.Unlock()
 
-	for _, cntr := range p.containers {
-		if cntr.Name == c.Name && cntr.ID == c.ID {
+	for _, cntr := range p.index.ids[c.ID] {
+		if cntr.Name == c.Name {
 			return false
 		}
 	}
+	p.slots[c] = len(p.containers)
 	p.containers = append(p.containers, c)
+	p.index.add(c)
 	return true
 }
 
@@ -149,19 +247,45 @@ func (p *ComposerProject) remove(nameid string) *Container {
 	p.m.Lock()
 	defer p.m.Unlock()
 
-	for idx, cntr := range p.containers {
-		if cntr.Name == nameid || cntr.ID == nameid {
-			// We've found the container by name or ID, so we new remove it from
-			// the slice. As we don't care about order, erm, container order,
-			// that is, we do an optimized slice delete, see also:
-			// https://github.com/golang/go/wiki/SliceTricks#delete-without-preserving-order
-			// Make sure to help the garbage collector by freeing the final
-			// slice slot before shortening the slice.
-			last := len(p.containers) - 1
-			p.containers[idx], p.containers[last] = p.containers[last], nil
-			p.containers = p.containers[:last]
-			return cntr
-		}
+	cntr := p.index.lookup(nameid)
+	if cntr == nil {
+		return nil
+	}
+	p.drop(cntr)
+	return cntr
+}
+
+// drop the specified container from this composer project. The caller must
+// hold the project's lock.
+func (p *ComposerProject) drop(cntr *Container) {
+	idx, ok := p.slots[cntr]
+	if !ok {
+		return
+	}
+	// We've found the container, so we new remove it from the slice. As we
+	// don't care about order, erm, container order, that is, we do an
+	// optimized slice delete, see also:
+	// https://github.com/golang/go/wiki/SliceTricks#delete-without-preserving-order
+	// Make sure to help the garbage collector by freeing the final slice slot
+	// before shortening the slice.
+	last := len(p.containers) - 1
+	p.containers[idx], p.containers[last] = p.containers[last], nil
+	p.containers = p.containers[:last]
+	if idx != last {
+		p.slots[p.containers[idx]] = idx
 	}
-	return nil
+	delete(p.slots, cntr)
+	p.index.remove(cntr)
+}
+
+// replace the specified container with a new version of it, keeping its
+// position in the project's list of containers. The caller must hold the
+// project's lock.
+func (p *ComposerProject) replace(old, updated *Container) {
+	idx := p.slots[old]
+	p.containers[idx] = updated
+	delete(p.slots, old)
+	p.slots[updated] = idx
+	p.index.remove(old)
+	p.index.add(updated)
 }
@@ -22,7 +22,7 @@ import (
 var _ = Describe("composer project proxy", func() {
 
 	It("prints", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 		Expect(p.String()).To(Equal("empty composer project 'gnampf'"))
 
@@ -33,7 +33,7 @@ var _ = Describe("composer project proxy", func() {
 	})
 
 	It("adds containers", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		ff := &Container{Name: "furious_furuncle"}
@@ -45,7 +45,7 @@ var _ = Describe("composer project proxy", func() {
 	})
 
 	It("doesn't update an existing container", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		p.add(&Container{Name: "furious_furuncle", ID: "1"})
@@ -62,7 +62,7 @@ var _ = Describe("composer project proxy", func() {
 	})
 
 	It("differentiates containers by name and ID", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		p.add(&Container{Name: "furious_furuncle", ID: "1"})
@@ -84,7 +84,7 @@ var _ = Describe("composer project proxy", func() {
 	})
 
 	It("removes containers", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		ff := &Container{Name: "furious_furuncle"}
@@ -106,7 +106,7 @@ var _ = Describe("composer project proxy", func() {
 	})
 
 	It("lists its containers", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		p.add(&Container{Name: "furious_furuncle"})
@@ -117,7 +117,7 @@ var _ = Describe("composer project proxy", func() {
 	})
 
 	It("finds a container", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		p.add(&Container{Name: "furious_furuncle"})
@@ -133,8 +133,23 @@ var _ = Describe("composer project proxy", func() {
 		Expect(mm.Name).To(Equal("mad_moby"))
 	})
 
+	It("keeps its index up to date when removing containers", func() {
+		p := newComposerProject(nil, "gnampf")
+		p.add(&Container{Name: "furious_furuncle", ID: "1"})
+		p.add(&Container{Name: "mad_moby", ID: "2"})
+		p.add(&Container{Name: "murky_moby", ID: "3"})
+
+		Expect(p.remove("1")).To(HaveField("Name", "furious_furuncle"))
+		Expect(p.Container("1")).To(BeNil())
+		Expect(p.Container("furious_furuncle")).To(BeNil())
+		Expect(p.Container("3")).To(HaveField("Name", "murky_moby"))
+		Expect(p.SetPaused("murky_moby", true)).To(HaveField("Paused", true))
+		Expect(p.remove("murky_moby")).To(HaveField("Paused", true))
+		Expect(p.ContainerNames()).To(ConsistOf("mad_moby"))
+	})
+
 	It("updates a container's pause state", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		p.add(&Container{Name: "furious_furuncle"})
@@ -146,15 +161,30 @@ var _ = Describe("composer project proxy", func() {
 		Expect(ff.Paused).To(BeFalse())
 	})
 
+	It("updates a container's health status", func() {
+		p := newComposerProject(nil, "gnampf")
+		Expect(p).NotTo(BeNil())
+
+		p.add(&Container{Name: "furious_furuncle"})
+		ff := p.Container("furious_furuncle")
+		Expect(p.SetHealth("furious_furuncle", HealthStarting)).To(HaveField("Health", HealthStarting))
+		Expect(p.Container("furious_furuncle").Health).To(Equal(HealthStarting))
+		Expect(ff.Health).To(BeEmpty())
+
+		hff := p.Container("furious_furuncle")
+		Expect(p.SetHealth("furious_furuncle", HealthStarting)).To(BeIdenticalTo(hff))
+		Expect(p.SetHealth("foobarz", HealthHealthy)).To(BeNil())
+	})
+
 	It("ignores trying to pause a non-existing container", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		Expect(p.SetPaused("foobarz", true)).To(BeNil())
 	})
 
 	It("returns the original container when pause state is unchanged", func() {
-		p := newComposerProject("gnampf")
+		p := newComposerProject(nil, "gnampf")
 		Expect(p).NotTo(BeNil())
 
 		p.add(&Container{Name: "furious_furuncle"})
@@ -0,0 +1,54 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoRucksack reports that a container has no Rucksack.
+var ErrNoRucksack = errors.New("container has no Rucksack")
+
+// RucksackOf returns the Rucksack of the specified container as type T. It
+// returns an error wrapping [ErrNoRucksack] if the container has no Rucksack,
+// and a descriptive error if the Rucksack isn't of type T.
+func RucksackOf[T any](cntr *Container) (T, error) {
+	var zero T
+	if cntr == nil || cntr.Rucksack == nil {
+		name := ""
+		if cntr != nil {
+			name = cntr.Name
+		}
+		return zero, fmt.Errorf("container %q: %w", name, ErrNoRucksack)
+	}
+	rucksack, ok := cntr.Rucksack.(T)
+	if !ok {
+		return zero, fmt.Errorf("container %q has Rucksack of type %T, expected type %s",
+			cntr.Name, cntr.Rucksack, reflect.TypeFor[T]())
+	}
+	return rucksack, nil
+}
+
+// MustRucksackOf returns the Rucksack of the specified container as type T,
+// panicking if the container has no Rucksack or it isn't of type T.
+func MustRucksackOf[T any](cntr *Container) T {
+	rucksack, err := RucksackOf[T](cntr)
+	if err != nil {
+		panic(err)
+	}
+	return rucksack
+}
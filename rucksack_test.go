@@ -0,0 +1,46 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("typed Rucksacks", func() {
+
+	It("returns a Rucksack of the expected type", func() {
+		c := &Container{Name: "furious_furuncle", Rucksack: &rucksack{Motto: "I'm not dead yet"}}
+		Expect(Successful(RucksackOf[*rucksack](c))).To(Equal(&rucksack{Motto: "I'm not dead yet"}))
+		Expect(MustRucksackOf[*rucksack](c).Motto).To(Equal("I'm not dead yet"))
+	})
+
+	It("reports missing Rucksacks", func() {
+		Expect(RucksackOf[*rucksack](nil)).Error().To(MatchError(ErrNoRucksack))
+		Expect(RucksackOf[*rucksack](&Container{Name: "foo"})).Error().To(And(
+			MatchError(ErrNoRucksack),
+			MatchError(ContainSubstring(`container "foo"`))))
+	})
+
+	It("reports Rucksacks of the wrong type", func() {
+		c := &Container{Name: "foo", Rucksack: rucksack{}}
+		Expect(RucksackOf[*rucksack](c)).Error().To(MatchError(
+			`container "foo" has Rucksack of type whalewatcher.rucksack, expected type *whalewatcher.rucksack`))
+		Expect(func() { _ = MustRucksackOf[string](c) }).To(PanicWith(MatchError(
+			ContainSubstring("expected type string"))))
+	})
+
+})
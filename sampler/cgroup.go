@@ -0,0 +1,134 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampler
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Unlimited is the value of limits, such as [Sample.MemoryMax], that are not
+// set ("max").
+const Unlimited = math.MaxUint64
+
+// readSample reads the cgroup v2 resource usage from the specified cgroup
+// directory. It returns false if the cgroup directory doesn't exist (anymore).
+func readSample(dir string, sample *Sample) bool {
+	if _, err := os.Stat(dir); err != nil {
+		return false
+	}
+	sample.CPU = readCPUStat(filepath.Join(dir, "cpu.stat"))
+	sample.MemoryCurrent = readValue(filepath.Join(dir, "memory.current"))
+	sample.MemoryMax = readValue(filepath.Join(dir, "memory.max"))
+	sample.PIDsCurrent = readValue(filepath.Join(dir, "pids.current"))
+	sample.IO = readIOStat(filepath.Join(dir, "io.stat"))
+	return true
+}
+
+// readValue returns the single value from the specified cgroup file, returning
+// Unlimited for "max" and zero in case of errors, such as when the controller
+// isn't enabled for the cgroup.
+func readValue(path string) uint64 {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	value := strings.TrimSpace(string(contents))
+	if value == "max" {
+		return Unlimited
+	}
+	v, _ := strconv.ParseUint(value, 10, 64)
+	return v
+}
+
+// readCPUStat returns the CPU usage from the specified "cpu.stat" file, which
+// consists of "key value" lines.
+func readCPUStat(path string) CPUStat {
+	var stat CPUStat
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return stat
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "usage_usec":
+			stat.UsageUsec = v
+		case "user_usec":
+			stat.UserUsec = v
+		case "system_usec":
+			stat.SystemUsec = v
+		case "nr_throttled":
+			stat.NrThrottled = v
+		case "throttled_usec":
+			stat.ThrottledUsec = v
+		}
+	}
+	return stat
+}
+
+// readIOStat returns the per-device I/O usage from the specified "io.stat"
+// file, which consists of lines in the format "MAJ:MIN key=value key=value
+// ...".
+func readIOStat(path string) []IOStat {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var stats []IOStat
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		stat := IOStat{Device: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				stat.Rbytes = v
+			case "wbytes":
+				stat.Wbytes = v
+			case "rios":
+				stat.Rios = v
+			case "wios":
+				stat.Wios = v
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
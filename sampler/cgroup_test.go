@@ -0,0 +1,73 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampler
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// fakeCgroup creates a fake cgroup v2 directory with the specified memory
+// usage in the specified fake cgroup filesystem, returning the directory.
+func fakeCgroup(cgroupfs string, path string, memory string) string {
+	GinkgoHelper()
+	dir := filepath.Join(cgroupfs, path)
+	Expect(os.MkdirAll(dir, 0o755)).To(Succeed())
+	for name, contents := range map[string]string{
+		"cpu.stat":       "usage_usec 1000\nuser_usec 600\nsystem_usec 400\nnr_periods 0\nnr_throttled 2\nthrottled_usec 42\n",
+		"memory.current": memory + "\n",
+		"memory.max":     "max\n",
+		"pids.current":   "3\n",
+		"io.stat":        "8:0 rbytes=1024 wbytes=2048 rios=1 wios=2 dbytes=0 dios=0\n259:0 rbytes=4096\n",
+	} {
+		Expect(os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644)).To(Succeed())
+	}
+	return dir
+}
+
+var _ = Describe("cgroup files", func() {
+
+	It("reads a sample", func() {
+		dir := fakeCgroup(GinkgoT().TempDir(), "foo", "12345")
+		var sample Sample
+		Expect(readSample(dir, &sample)).To(BeTrue())
+		Expect(sample.CPU).To(Equal(CPUStat{
+			UsageUsec:     1000,
+			UserUsec:      600,
+			SystemUsec:    400,
+			NrThrottled:   2,
+			ThrottledUsec: 42,
+		}))
+		Expect(sample.MemoryCurrent).To(Equal(uint64(12345)))
+		Expect(sample.MemoryMax).To(Equal(uint64(Unlimited)))
+		Expect(sample.PIDsCurrent).To(Equal(uint64(3)))
+		Expect(sample.IO).To(Equal([]IOStat{
+			{Device: "8:0", Rbytes: 1024, Wbytes: 2048, Rios: 1, Wios: 2},
+			{Device: "259:0", Rbytes: 4096},
+		}))
+	})
+
+	It("handles missing cgroups and files", func() {
+		tmpdir := GinkgoT().TempDir()
+		var sample Sample
+		Expect(readSample(filepath.Join(tmpdir, "missing"), &sample)).To(BeFalse())
+		Expect(readSample(tmpdir, &sample)).To(BeTrue())
+		Expect(sample).To(BeZero())
+	})
+
+})
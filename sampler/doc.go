@@ -0,0 +1,31 @@
+/*
+Package sampler periodically samples the cgroup v2 resource usage of the
+containers tracked by a [watcher.Watcher].
+
+# Usage
+
+	ww := watcher.New(moby.NewMobyWatcher(client), nil, watcher.WithCgroups())
+	go ww.Watch(ctx)
+	s := sampler.New(ww, sampler.WithInterval(5*time.Second))
+	samples := s.Samples()
+	go s.Run(ctx)
+	for batch := range samples {
+		for id, sample := range batch {
+			...
+		}
+	}
+
+A [Sampler] reconciles its set of sampled containers with the watcher's
+portfolio at the beginning of every sampling round: it starts sampling
+containers that have become alive and stops sampling containers that have died.
+The Sampler thus stays consistent with the portfolio even when the watcher
+resynchronizes with its container engine. As the Sampler doesn't consume the
+watcher's events, a cancelled Sampler can never hold up its watcher.
+
+Samples are read from the cgroup v2 "cpu.stat", "memory.current",
+"memory.max", "pids.current", and "io.stat" files of the containers' cgroups.
+If a container's cgroup wasn't already discovered by the watcher (see
+[watcher.WithCgroups]), the Sampler discovers it on its own. Containers in
+cgroup v1 hierarchies are not sampled.
+*/
+package sampler
@@ -0,0 +1,299 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampler
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+)
+
+// DefaultInterval is the default interval between sampling rounds.
+const DefaultInterval = 5 * time.Second
+
+// Sample is the resource usage of a single container at a particular time.
+type Sample struct {
+	ID            string    // container ID.
+	Name          string    // container name.
+	Time          time.Time // time of sampling.
+	CPU           CPUStat   // CPU usage from "cpu.stat".
+	MemoryCurrent uint64    // memory usage in bytes from "memory.current".
+	MemoryMax     uint64    // memory limit in bytes from "memory.max", or Unlimited.
+	PIDsCurrent   uint64    // number of processes from "pids.current".
+	IO            []IOStat  // per-device I/O usage from "io.stat".
+}
+
+// CPUStat is the CPU usage of a container, in microseconds where applicable.
+type CPUStat struct {
+	UsageUsec     uint64 // total CPU time.
+	UserUsec      uint64 // user CPU time.
+	SystemUsec    uint64 // system CPU time.
+	NrThrottled   uint64 // number of times the container got throttled.
+	ThrottledUsec uint64 // total time the container was throttled.
+}
+
+// IOStat is the I/O usage of a container for a specific block device.
+type IOStat struct {
+	Device string // block device number in "MAJ:MIN" format.
+	Rbytes uint64 // bytes read.
+	Wbytes uint64 // bytes written.
+	Rios   uint64 // number of read I/O operations.
+	Wios   uint64 // number of write I/O operations.
+}
+
+// Samples maps container IDs to the samples taken of these containers in the
+// same sampling round.
+type Samples map[string]Sample
+
+// Sampler periodically samples the cgroup v2 resource usage of the containers
+// tracked by a [watcher.Watcher].
+type Sampler struct {
+	ww       watcher.Watcher
+	interval time.Duration
+	procfs   string
+	cgroupfs string
+
+	m          sync.Mutex
+	containers map[string]*sampled // containers being sampled, by ID.
+	latest     Samples             // most recent sampling round.
+
+	samplechmux sync.Mutex
+	samplechs   []chan Samples
+	done        bool // Run has finished, so no more samples.
+}
+
+// sampled is a container being sampled, together with its cgroup directory.
+type sampled struct {
+	container *whalewatcher.Container
+	dir       string // cgroup v2 directory, or "" if not yet known or not v2.
+	resolved  bool   // true if the cgroup has been resolved.
+}
+
+// New returns a new Sampler for sampling the containers of the specified
+// watcher, taking the specified options into account. Sampling only starts
+// when calling [Sampler.Run].
+func New(ww watcher.Watcher, opts ...Option) *Sampler {
+	s := &Sampler{
+		ww:         ww,
+		interval:   DefaultInterval,
+		procfs:     "/proc",
+		cgroupfs:   "/sys/fs/cgroup",
+		containers: map[string]*sampled{},
+		latest:     Samples{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Option represents options to New when creating new samplers.
+type Option func(*Sampler)
+
+// WithInterval sets the interval between sampling rounds; it defaults to
+// [DefaultInterval].
+func WithInterval(interval time.Duration) Option {
+	return func(s *Sampler) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+// WithProcfs sets the root of the proc filesystem to use when discovering the
+// cgroups of containers; it defaults to "/proc".
+func WithProcfs(root string) Option {
+	return func(s *Sampler) {
+		s.procfs = root
+	}
+}
+
+// WithCgroupfs sets the root of the cgroup filesystem to use when discovering
+// the cgroups of containers; it defaults to "/sys/fs/cgroup".
+func WithCgroupfs(root string) Option {
+	return func(s *Sampler) {
+		s.cgroupfs = root
+	}
+}
+
+// Samples returns a new channel receiving the samples of each sampling round.
+// Slow receivers only get the most recent sampling round, skipping earlier
+// ones. The channel gets closed when [Sampler.Run] returns. Receivers must not
+// modify the received samples.
+func (s *Sampler) Samples() <-chan Samples {
+	s.samplechmux.Lock()
+	defer s.samplechmux.Unlock()
+	ch := make(chan Samples, 1)
+	if s.done {
+		close(ch)
+		return ch
+	}
+	s.samplechs = append(s.samplechs, ch)
+	return ch
+}
+
+// Latest returns the samples of the most recent sampling round.
+func (s *Sampler) Latest() Samples {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return maps.Clone(s.latest)
+}
+
+// Sample returns the most recent sample of the container with the specified
+// ID, if any.
+func (s *Sampler) Sample(id string) (Sample, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	sample, ok := s.latest[id]
+	return sample, ok
+}
+
+// Run periodically samples the containers of the watcher until the specified
+// context gets cancelled. At the beginning of each sampling round, Run
+// reconciles the containers to sample with the watcher's portfolio, starting to
+// sample new containers and stopping to sample containers that have died.
+// Please note that Run deliberately doesn't subscribe to the watcher's events,
+// as an event channel left behind after Run returns would otherwise block the
+// watcher.
+func (s *Sampler) Run(ctx context.Context) error {
+	defer s.close()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	s.reconcile()
+	s.publish(s.sample())
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			s.reconcile()
+			s.publish(s.sample())
+		}
+	}
+}
+
+// close the sample channels.
+func (s *Sampler) close() {
+	s.samplechmux.Lock()
+	defer s.samplechmux.Unlock()
+	for _, ch := range s.samplechs {
+		close(ch)
+	}
+	s.samplechs = nil
+	s.done = true
+}
+
+// start sampling the specified container, unless already sampling it.
+func (s *Sampler) start(cntr *whalewatcher.Container) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if smpld, ok := s.containers[cntr.ID]; ok {
+		smpld.container = cntr
+		return
+	}
+	smpld := &sampled{container: cntr}
+	s.containers[cntr.ID] = smpld
+	s.resolve(smpld)
+}
+
+// reconcile the containers being sampled with the containers in the watcher's
+// portfolio, starting to sample new containers and stopping to sample
+// containers that are gone.
+func (s *Sampler) reconcile() {
+	snapshot := s.ww.Portfolio().Snapshot()
+	alive := map[string]struct{}{}
+	for cntr := range snapshot.AllContainers() {
+		alive[cntr.ID] = struct{}{}
+		s.start(cntr)
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for id := range s.containers {
+		if _, ok := alive[id]; !ok {
+			delete(s.containers, id)
+			delete(s.latest, id)
+		}
+	}
+}
+
+// resolve the cgroup v2 directory of the specified container, if not already
+// done. The caller must hold the sampler's lock.
+func (s *Sampler) resolve(smpld *sampled) {
+	if smpld.resolved {
+		return
+	}
+	cgroup := smpld.container.Cgroup
+	if cgroup == nil {
+		var err error
+		cgroup, err = whalewatcher.ReadCgroup(s.procfs, s.cgroupfs, smpld.container.PID)
+		if err != nil {
+			return // try again in the next sampling round.
+		}
+	}
+	smpld.resolved = true
+	if cgroup.V2 {
+		smpld.dir = cgroup.Dir
+	}
+}
+
+// sample all containers and return the samples.
+func (s *Sampler) sample() Samples {
+	s.m.Lock()
+	defer s.m.Unlock()
+	samples := Samples{}
+	now := time.Now()
+	for id, smpld := range s.containers {
+		s.resolve(smpld)
+		if smpld.dir == "" {
+			continue
+		}
+		sample := Sample{
+			ID:   id,
+			Name: smpld.container.Name,
+			Time: now,
+		}
+		if !readSample(smpld.dir, &sample) {
+			continue
+		}
+		samples[id] = sample
+	}
+	s.latest = samples
+	return maps.Clone(samples)
+}
+
+// publish the samples to all sample channels, replacing any samples not yet
+// received.
+func (s *Sampler) publish(samples Samples) {
+	s.samplechmux.Lock()
+	defer s.samplechmux.Unlock()
+	for _, ch := range s.samplechs {
+		select {
+		case ch <- samples:
+			continue
+		default:
+		}
+		// Drop the stale samples not yet received; as we're the only sender
+		// and hold the lock, there's room afterwards.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- samples
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient/moby"
+	"github.com/thediveo/whalewatcher/v2/test/mockingmoby"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+)
+
+// fakeWatcher is a watcher with a portfolio fully controlled by tests.
+type fakeWatcher struct {
+	watcher.Watcher
+	pf *whalewatcher.Portfolio
+}
+
+func (w *fakeWatcher) Portfolio() *whalewatcher.Portfolio { return w.pf }
+
+var _ = Describe("sampler", func() {
+
+	BeforeEach(func() {
+		goodgos := Goroutines()
+		DeferCleanup(func() {
+			Eventually(Goroutines).Within(2 * time.Second).ProbeEvery(100 * time.Millisecond).
+				ShouldNot(HaveLeaked(goodgos))
+		})
+	})
+
+	It("samples containers as they come and go", func() {
+		cgroupfs := GinkgoT().TempDir()
+		procfs := GinkgoT().TempDir()
+		furuncledir := fakeCgroup(cgroupfs, "system.slice/docker-1.scope", "1000")
+		fakeCgroup(cgroupfs, "system.slice/docker-2.scope", "2000")
+		Expect(os.MkdirAll(filepath.Join(procfs, "42"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(procfs, "42", "cgroup"),
+			[]byte("0::/system.slice/docker-2.scope\n"), 0o644)).To(Succeed())
+
+		furuncle := &whalewatcher.Container{ID: "1", Name: "furious_furuncle", PID: 666,
+			Cgroup: &whalewatcher.Cgroup{Path: "/system.slice/docker-1.scope", Dir: furuncledir, V2: true}}
+		moby := &whalewatcher.Container{ID: "2", Name: "murky_moby", PID: 42}
+		v1 := &whalewatcher.Container{ID: "3", Name: "vintage_v1", PID: 1,
+			Cgroup: &whalewatcher.Cgroup{Path: "/docker/3", Dir: "/sys/fs/cgroup/memory/docker/3"}}
+
+		ww := &fakeWatcher{pf: whalewatcher.NewPortfolio()}
+		ww.pf.Add(furuncle)
+		ww.pf.Add(v1)
+
+		s := New(ww, WithInterval(100*time.Millisecond), WithProcfs(procfs), WithCgroupfs(cgroupfs))
+		samples := s.Samples()
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = s.Run(ctx)
+		}()
+
+		Eventually(samples).Should(Receive(And(
+			HaveLen(1),
+			HaveKeyWithValue("1", And(
+				HaveField("Name", "furious_furuncle"),
+				HaveField("MemoryCurrent", uint64(1000))))),
+		))
+
+		ww.pf.Add(moby)
+		Eventually(samples).Should(Receive(And(
+			HaveLen(2),
+			HaveKeyWithValue("2", HaveField("MemoryCurrent", uint64(2000))))))
+		sample, ok := s.Sample("2")
+		Expect(ok).To(BeTrue())
+		Expect(sample.Name).To(Equal("murky_moby"))
+
+		ww.pf.Remove(furuncle.ID, furuncle.Project)
+		Eventually(s.Latest).ShouldNot(HaveKey("1"))
+		Eventually(samples).Should(Receive(And(HaveLen(1), HaveKey("2"))))
+
+		ww.pf.Remove(moby.ID, moby.Project)
+		Eventually(s.Latest).Should(BeEmpty())
+
+		cancel()
+		Eventually(done).Should(BeClosed())
+		Eventually(samples).Should(BeClosed())
+		Expect(s.Samples()).To(BeClosed())
+	})
+
+	It("never blocks the watcher after having been cancelled", func(ctx context.Context) {
+		mm := mockingmoby.NewMockingMoby()
+		ww := watcher.New(moby.NewMobyWatcher(mm), nil)
+		DeferCleanup(ww.Close)
+		wctx, wcancel := context.WithCancel(ctx)
+		watchdone := make(chan struct{})
+		go func() {
+			defer close(watchdone)
+			_ = ww.Watch(wctx)
+		}()
+		DeferCleanup(func() {
+			wcancel()
+			Eventually(watchdone).Should(BeClosed())
+		})
+		Eventually(ww.Ready()).Should(BeClosed())
+
+		s := New(ww, WithInterval(10*time.Millisecond))
+		sctx, scancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = s.Run(sctx)
+		}()
+		scancel()
+		Eventually(done).Should(BeClosed())
+
+		for idx := range 50 {
+			mm.AddContainer(mockingmoby.MockedContainer{
+				ID:     fmt.Sprintf("%d", idx),
+				Name:   fmt.Sprintf("clone_%d", idx),
+				Status: mockingmoby.MockedRunning,
+				PID:    idx + 1,
+			})
+		}
+		Eventually(ww.Portfolio().ContainerTotal).Should(Equal(50))
+	})
+
+})
@@ -0,0 +1,328 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Selector selects containers based on their labels, in the style of
+// Kubernetes label selectors. A Selector consists of requirements that all
+// must be met in order for a container to be selected. The empty Selector
+// selects all containers.
+//
+// As selectors work on the generic container labels, they work the same for
+// Docker labels, containerd labels, as well as the CRI pod labels and
+// (prefixed) annotations synthesized by the CRI engine client.
+type Selector []Requirement
+
+// Requirement is a single requirement of a [Selector] on a container's labels.
+type Requirement struct {
+	Key      string   // label key.
+	Operator Operator // how to check the label.
+	Values   []string // label values for comparison, if applicable.
+}
+
+// Operator specifies how a [Requirement] checks a label.
+type Operator byte
+
+const (
+	LabelExists       Operator = iota // label must be present: "key"
+	LabelDoesNotExist                 // label must not be present: "!key"
+	LabelEquals                       // label must have value: "key=value" or "key==value"
+	LabelNotEquals                    // label must be absent or not have value: "key!=value"
+	LabelIn                           // label must have one of the values: "key in (v1,v2)"
+	LabelNotIn                        // label must be absent or have none of the values: "key notin (v1,v2)"
+)
+
+// ParseSelector parses the textual representation of a label selector and
+// returns the corresponding [Selector], or an error if the textual
+// representation is invalid. The syntax follows Kubernetes label selectors,
+// with comma-separated requirements:
+//
+//   - "key": the label must be present.
+//   - "!key": the label must not be present.
+//   - "key=value", "key==value": the label must be present with the value.
+//   - "key!=value": the label must not be present with the value.
+//   - "key in (value1,value2)": the label must be present with one of the
+//     values.
+//   - "key notin (value1,value2)": the label must not be present with any of
+//     the values.
+//
+// For example, "app=web,tier!=db,env in (prod,stage),!debug".
+//
+// Keys and values can contain any characters except whitespace and "=", "!",
+// ",", "(", ")". This especially allows keys such as
+// "io.kubernetes.annotation/foo".
+func ParseSelector(s string) (Selector, error) {
+	p := selectorParser{s: s}
+	sel, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", s, err)
+	}
+	return sel, nil
+}
+
+// MustParseSelector parses the textual representation of a label selector,
+// panicking in case the textual representation is invalid.
+func MustParseSelector(s string) Selector {
+	sel, err := ParseSelector(s)
+	if err != nil {
+		panic(err)
+	}
+	return sel
+}
+
+// Matches returns true if the specified labels meet all requirements of this
+// selector.
+func (sel Selector) Matches(labels map[string]string) bool {
+	for _, req := range sel {
+		if !req.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the textual representation of this selector.
+func (sel Selector) String() string {
+	reqs := make([]string, 0, len(sel))
+	for _, req := range sel {
+		reqs = append(reqs, req.String())
+	}
+	return strings.Join(reqs, ",")
+}
+
+// Matches returns true if the specified labels meet this requirement.
+func (req Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[req.Key]
+	switch req.Operator {
+	case LabelExists:
+		return ok
+	case LabelDoesNotExist:
+		return !ok
+	case LabelEquals, LabelIn:
+		return ok && slices.Contains(req.Values, value)
+	case LabelNotEquals, LabelNotIn:
+		return !ok || !slices.Contains(req.Values, value)
+	}
+	return false
+}
+
+// String returns the textual representation of this requirement.
+func (req Requirement) String() string {
+	switch req.Operator {
+	case LabelExists:
+		return req.Key
+	case LabelDoesNotExist:
+		return "!" + req.Key
+	case LabelEquals:
+		return req.Key + "=" + strings.Join(req.Values, "")
+	case LabelNotEquals:
+		return req.Key + "!=" + strings.Join(req.Values, "")
+	case LabelIn:
+		return req.Key + " in (" + strings.Join(req.Values, ",") + ")"
+	case LabelNotIn:
+		return req.Key + " notin (" + strings.Join(req.Values, ",") + ")"
+	}
+	return ""
+}
+
+// Matches returns true if this container's labels meet all requirements of the
+// specified selector.
+func (c Container) Matches(sel Selector) bool {
+	return sel.Matches(c.Labels)
+}
+
+// Select returns an iterator over all containers of this portfolio that match
+// the specified selector. The iterator works on a [Snapshot] of this
+// portfolio taken at the time of calling Select.
+func (pf *Portfolio) Select(sel Selector) iter.Seq[*Container] {
+	return pf.Snapshot().Select(sel)
+}
+
+// Select returns an iterator over all containers of this snapshot that match
+// the specified selector.
+func (s *Snapshot) Select(sel Selector) iter.Seq[*Container] {
+	return func(yield func(*Container) bool) {
+		for cntr := range s.AllContainers() {
+			if cntr.Matches(sel) && !yield(cntr) {
+				return
+			}
+		}
+	}
+}
+
+// selectorParser parses the textual representation of label selectors.
+type selectorParser struct {
+	s   string
+	pos int
+}
+
+// parse the textual representation of a label selector.
+func (p *selectorParser) parse() (Selector, error) {
+	sel := Selector{}
+	p.skipSpace()
+	if p.done() {
+		return sel, nil
+	}
+	for {
+		req, err := p.requirement()
+		if err != nil {
+			return nil, err
+		}
+		sel = append(sel, req)
+		p.skipSpace()
+		if p.done() {
+			return sel, nil
+		}
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// requirement parses a single requirement.
+func (p *selectorParser) requirement() (Requirement, error) {
+	p.skipSpace()
+	if p.peek() == '!' {
+		p.pos++
+		p.skipSpace()
+		key := p.word()
+		if key == "" {
+			return Requirement{}, p.errorf("missing label key")
+		}
+		return Requirement{Key: key, Operator: LabelDoesNotExist}, nil
+	}
+	key := p.word()
+	if key == "" {
+		return Requirement{}, p.errorf("missing label key")
+	}
+	p.skipSpace()
+	switch p.peek() {
+	case 0, ',':
+		return Requirement{Key: key, Operator: LabelExists}, nil
+	case '=':
+		p.pos++
+		if p.peek() == '=' {
+			p.pos++
+		}
+		return Requirement{Key: key, Operator: LabelEquals, Values: []string{p.value()}}, nil
+	case '!':
+		p.pos++
+		if err := p.expect('='); err != nil {
+			return Requirement{}, err
+		}
+		return Requirement{Key: key, Operator: LabelNotEquals, Values: []string{p.value()}}, nil
+	}
+	var op Operator
+	switch opname := p.word(); opname {
+	case "in":
+		op = LabelIn
+	case "notin":
+		op = LabelNotIn
+	default:
+		return Requirement{}, p.errorf("unknown operator %q", opname)
+	}
+	p.skipSpace()
+	if err := p.expect('('); err != nil {
+		return Requirement{}, err
+	}
+	p.skipSpace()
+	if p.peek() == ')' {
+		return Requirement{}, p.errorf("empty value list")
+	}
+	values := []string{}
+	for {
+		values = append(values, p.value())
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	if err := p.expect(')'); err != nil {
+		return Requirement{}, err
+	}
+	return Requirement{Key: key, Operator: op, Values: values}, nil
+}
+
+// value returns the next (potentially empty) value, skipping any surrounding
+// whitespace.
+func (p *selectorParser) value() string {
+	p.skipSpace()
+	v := p.word()
+	p.skipSpace()
+	return v
+}
+
+// word returns the next key, value, or operator name, which might be empty.
+func (p *selectorParser) word() string {
+	start := p.pos
+	for p.pos < len(p.s) {
+		ch, size := utf8.DecodeRuneInString(p.s[p.pos:])
+		if unicode.IsSpace(ch) || strings.ContainsRune("=!,()", ch) {
+			break
+		}
+		p.pos += size
+	}
+	return p.s[start:p.pos]
+}
+
+// expect the specified character next, returning an error otherwise.
+func (p *selectorParser) expect(ch byte) error {
+	if p.peek() != ch {
+		if p.done() {
+			return p.errorf("expected %q but reached end", ch)
+		}
+		return p.errorf("expected %q but got %q", ch, p.s[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// peek returns the next character without consuming it, or zero when there
+// are no more characters.
+func (p *selectorParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// skipSpace skips any whitespace.
+func (p *selectorParser) skipSpace() {
+	for p.pos < len(p.s) {
+		ch, size := utf8.DecodeRuneInString(p.s[p.pos:])
+		if !unicode.IsSpace(ch) {
+			break
+		}
+		p.pos += size
+	}
+}
+
+// done returns true if there are no more characters to parse.
+func (p *selectorParser) done() bool {
+	return p.pos >= len(p.s)
+}
+
+// errorf returns a parse error annotated with the current parsing position.
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.pos)
+}
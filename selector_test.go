@@ -0,0 +1,134 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("label selectors", func() {
+
+	DescribeTable("parsing selectors",
+		func(s string, expected Selector, canonical string) {
+			sel := Successful(ParseSelector(s))
+			Expect(sel).To(Equal(expected))
+			Expect(sel.String()).To(Equal(canonical))
+		},
+		Entry(nil, "", Selector{}, ""),
+		Entry(nil, "  ", Selector{}, ""),
+		Entry(nil, "foo", Selector{{Key: "foo", Operator: LabelExists}}, "foo"),
+		Entry(nil, " ! foo ", Selector{{Key: "foo", Operator: LabelDoesNotExist}}, "!foo"),
+		Entry(nil, "foo=bar", Selector{{Key: "foo", Operator: LabelEquals, Values: []string{"bar"}}}, "foo=bar"),
+		Entry(nil, "foo == bar", Selector{{Key: "foo", Operator: LabelEquals, Values: []string{"bar"}}}, "foo=bar"),
+		Entry(nil, "foo=", Selector{{Key: "foo", Operator: LabelEquals, Values: []string{""}}}, "foo="),
+		Entry(nil, "foo!=bar", Selector{{Key: "foo", Operator: LabelNotEquals, Values: []string{"bar"}}}, "foo!=bar"),
+		Entry(nil, "foo in (bar, baz)",
+			Selector{{Key: "foo", Operator: LabelIn, Values: []string{"bar", "baz"}}}, "foo in (bar,baz)"),
+		Entry(nil, "foo notin(bar)",
+			Selector{{Key: "foo", Operator: LabelNotIn, Values: []string{"bar"}}}, "foo notin (bar)"),
+		Entry(nil, "io.kubernetes.annotation/foo.bar/baz=42,!debug",
+			Selector{
+				{Key: "io.kubernetes.annotation/foo.bar/baz", Operator: LabelEquals, Values: []string{"42"}},
+				{Key: "debug", Operator: LabelDoesNotExist},
+			}, "io.kubernetes.annotation/foo.bar/baz=42,!debug"),
+		Entry(nil, "app=web,tier!=db,env in (prod,stage),!debug",
+			Selector{
+				{Key: "app", Operator: LabelEquals, Values: []string{"web"}},
+				{Key: "tier", Operator: LabelNotEquals, Values: []string{"db"}},
+				{Key: "env", Operator: LabelIn, Values: []string{"prod", "stage"}},
+				{Key: "debug", Operator: LabelDoesNotExist},
+			}, "app=web,tier!=db,env in (prod,stage),!debug"),
+		Entry(nil, "name=à", Selector{{Key: "name", Operator: LabelEquals, Values: []string{"à"}}}, "name=à"),
+		Entry(nil, "name in (Åse,b)",
+			Selector{{Key: "name", Operator: LabelIn, Values: []string{"Åse", "b"}}}, "name in (Åse,b)"),
+		Entry(nil, "größe\u00a0!=\u2003ÿ\u0085",
+			Selector{{Key: "größe", Operator: LabelNotEquals, Values: []string{"ÿ"}}}, "größe!=ÿ"),
+		Entry(nil, "!ключ,值", Selector{
+			{Key: "ключ", Operator: LabelDoesNotExist},
+			{Key: "值", Operator: LabelExists},
+		}, "!ключ,值"),
+	)
+
+	DescribeTable("rejecting invalid selectors",
+		func(s string, errmsg string) {
+			Expect(ParseSelector(s)).Error().To(MatchError(ContainSubstring(errmsg)))
+		},
+		Entry(nil, ",", "missing label key at position 0"),
+		Entry(nil, "!", "missing label key"),
+		Entry(nil, "foo,", "missing label key"),
+		Entry(nil, "=bar", "missing label key"),
+		Entry(nil, "foo!bar", `expected '=' but got 'b'`),
+		Entry(nil, "foo bar", `unknown operator "bar"`),
+		Entry(nil, "foo in bar", `expected '(' but got 'b'`),
+		Entry(nil, "foo in (bar", `expected ')' but reached end`),
+		Entry(nil, "foo in ()", "empty value list at position 8"),
+		Entry(nil, "foo notin ( )", "empty value list"),
+		Entry(nil, "foo=bar baz", `expected ',' but got 'b'`),
+	)
+
+	It("panics on invalid selectors when told so", func() {
+		Expect(func() { MustParseSelector("=") }).To(PanicWith(MatchError(ContainSubstring("invalid label selector"))))
+		Expect(MustParseSelector("foo")).To(HaveLen(1))
+	})
+
+	DescribeTable("matching labels",
+		func(s string, labels map[string]string, matches bool) {
+			Expect(MustParseSelector(s).Matches(labels)).To(Equal(matches))
+		},
+		Entry(nil, "", nil, true),
+		Entry(nil, "foo", map[string]string{"foo": ""}, true),
+		Entry(nil, "foo", map[string]string{}, false),
+		Entry(nil, "!foo", map[string]string{}, true),
+		Entry(nil, "!foo", map[string]string{"foo": "bar"}, false),
+		Entry(nil, "foo=bar", map[string]string{"foo": "bar"}, true),
+		Entry(nil, "foo=bar", map[string]string{"foo": "baz"}, false),
+		Entry(nil, "foo=bar", map[string]string{}, false),
+		Entry(nil, "foo!=bar", map[string]string{}, true),
+		Entry(nil, "foo!=bar", map[string]string{"foo": "baz"}, true),
+		Entry(nil, "foo!=bar", map[string]string{"foo": "bar"}, false),
+		Entry(nil, "foo in (bar,baz)", map[string]string{"foo": "baz"}, true),
+		Entry(nil, "foo in (bar,baz)", map[string]string{}, false),
+		Entry(nil, "foo notin (bar,baz)", map[string]string{"foo": "baz"}, false),
+		Entry(nil, "foo notin (bar,baz)", map[string]string{"foo": "buzz"}, true),
+		Entry(nil, "foo notin (bar,baz)", map[string]string{}, true),
+		Entry(nil, "foo=bar,!debug", map[string]string{"foo": "bar", "debug": ""}, false),
+		Entry(nil, "name=à", map[string]string{"name": "à"}, true),
+		Entry(nil, "name=à", map[string]string{"name": "á"}, false),
+	)
+
+	It("selects containers from a portfolio", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Labels: map[string]string{"app": "web", "env": "prod"}})
+		pf.Add(&Container{ID: "2", Name: "murky_moby", Labels: map[string]string{"app": "web", "debug": ""}, Project: "grumpy"})
+		pf.Add(&Container{ID: "3", Name: "pompous_paperboard", Labels: map[string]string{"app": "db"}})
+
+		Expect(pf.Select(MustParseSelector("app=web,!debug"))).To(ConsistOf(
+			HaveField("Name", "furious_furuncle")))
+		Expect(pf.Select(MustParseSelector("app in (web,db)"))).To(HaveLen(3))
+		Expect(pf.Select(nil)).To(HaveLen(3))
+
+		var names []string
+		for cntr := range pf.Select(nil) {
+			names = append(names, cntr.Name)
+			break
+		}
+		Expect(names).To(HaveLen(1))
+
+		Expect(pf.Container("murky_moby").Matches(MustParseSelector("debug"))).To(BeTrue())
+	})
+
+})
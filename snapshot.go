@@ -0,0 +1,122 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	"iter"
+	"slices"
+)
+
+// Snapshot is an immutable, point-in-time view of a [Portfolio], together with
+// the generation number of the Portfolio at the time the snapshot was taken.
+// Snapshots are consistent: they never show a Portfolio "half-way" through a
+// change.
+//
+// Comparing the generation numbers of two snapshots tells whether anything
+// has changed in between: a higher generation number indicates a more recent
+// state. As new portfolios always start with higher generation numbers than
+// all existing portfolios, this also holds when a watcher swaps its portfolio
+// while resynchronizing with a container engine.
+type Snapshot struct {
+	generation uint64
+	projects   map[string][]*Container // containers by project name.
+}
+
+// Snapshot returns a consistent, point-in-time view of this portfolio.
+func (pf *Portfolio) Snapshot() *Snapshot {
+	pf.m.RLock()
+	defer pf.m.RUnlock()
+	s := &Snapshot{
+		generation: pf.generation,
+		projects:   make(map[string][]*Container, len(pf.projects)),
+	}
+	for name, project := range pf.projects {
+		s.projects[name] = project.Containers()
+	}
+	return s
+}
+
+// Generation returns the generation number of the portfolio at the time this
+// snapshot was taken.
+func (s *Snapshot) Generation() uint64 {
+	return s.generation
+}
+
+// Names returns the names of all composer projects sans the "zero" project.
+func (s *Snapshot) Names() []string {
+	names := make([]string, 0, len(s.projects))
+	for name := range s.projects {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Project returns the containers of the composer project with the specified
+// name (including the zero project name), or nil if no project with the
+// specified name existed at the time of the snapshot.
+func (s *Snapshot) Project(name string) []*Container {
+	containers, ok := s.projects[name]
+	if !ok {
+		return nil
+	}
+	return slices.Clone(containers)
+}
+
+// Container returns the [Container] with the specified ID or name, regardless
+// of which project it is in. IDs take precedence over names, the same as with
+// [Portfolio.Container]. It returns nil, if no container with the specified ID
+// or name could be found.
+func (s *Snapshot) Container(nameorid string) *Container {
+	for _, containers := range s.projects {
+		for _, cntr := range containers {
+			if cntr.ID == nameorid {
+				return cntr
+			}
+		}
+	}
+	for _, containers := range s.projects {
+		for _, cntr := range containers {
+			if cntr.Name == nameorid {
+				return cntr
+			}
+		}
+	}
+	return nil
+}
+
+// ContainerTotal returns the total number of containers over all projects,
+// including non-project "standalone" containers.
+func (s *Snapshot) ContainerTotal() (total int) {
+	for _, containers := range s.projects {
+		total += len(containers)
+	}
+	return
+}
+
+// AllContainers returns an iterator that iterates over all containers in all
+// projects (including the zero/unnamed project) of this snapshot.
+func (s *Snapshot) AllContainers() iter.Seq[*Container] {
+	return func(yield func(*Container) bool) {
+		for _, containers := range s.projects {
+			for _, container := range containers {
+				if !yield(container) {
+					return
+				}
+			}
+		}
+	}
+}
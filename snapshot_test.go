@@ -0,0 +1,97 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whalewatcher
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("portfolio snapshots", func() {
+
+	It("advances generations only on changes", func() {
+		pf := NewPortfolio()
+		gen := pf.Generation()
+		Expect(gen).NotTo(BeZero())
+
+		Expect(pf.Add(&Container{ID: "1", Name: "furious_furuncle"})).To(BeTrue())
+		Expect(pf.Generation()).To(BeNumerically(">", gen))
+		gen = pf.Generation()
+
+		Expect(pf.Add(&Container{ID: "1", Name: "furious_furuncle"})).To(BeFalse())
+		Expect(pf.Generation()).To(Equal(gen))
+
+		pf.Project("").SetPaused("furious_furuncle", true)
+		Expect(pf.Generation()).To(BeNumerically(">", gen))
+		gen = pf.Generation()
+
+		pf.Project("").SetPaused("furious_furuncle", true)
+		Expect(pf.Generation()).To(Equal(gen))
+
+		Expect(pf.Remove("missing_moby", "")).To(BeNil())
+		Expect(pf.Generation()).To(Equal(gen))
+
+		Expect(pf.Remove("furious_furuncle", "")).NotTo(BeNil())
+		Expect(pf.Generation()).To(BeNumerically(">", gen))
+	})
+
+	It("starts new portfolios with higher generations", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle"})
+		Expect(NewPortfolio().Generation()).To(BeNumerically(">", pf.Generation()))
+	})
+
+	It("looks up containers by ID before name", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		pf.Add(&Container{ID: "2", Name: "1"})
+		pf.Add(&Container{ID: "3", Name: "1", Project: "dopey"})
+
+		s := pf.Snapshot()
+		for range 20 {
+			Expect(s.Container("1")).To(HaveField("Name", "furious_furuncle"))
+			Expect(s.Container("1")).To(BeIdenticalTo(pf.Container("1")))
+		}
+		Expect(s.Container("furious_furuncle")).To(HaveField("ID", "1"))
+	})
+
+	It("takes immutable snapshots", func() {
+		pf := NewPortfolio()
+		pf.Add(&Container{ID: "1", Name: "furious_furuncle", Project: "grumpy"})
+		pf.Add(&Container{ID: "2", Name: "pompous_paperboard"})
+
+		s := pf.Snapshot()
+		Expect(s.Generation()).To(Equal(pf.Generation()))
+		Expect(s.Names()).To(ConsistOf("grumpy"))
+		Expect(s.ContainerTotal()).To(Equal(2))
+		Expect(s.Project("grumpy")).To(ConsistOf(HaveField("Name", "furious_furuncle")))
+		Expect(s.Project("rumpelpumpel")).To(BeNil())
+		Expect(s.Container("2")).To(HaveField("Name", "pompous_paperboard"))
+		Expect(s.Container("murky_moby")).To(BeNil())
+
+		pf.Add(&Container{ID: "3", Name: "murky_moby", Project: "grumpy"})
+		pf.Project("").SetPaused("2", true)
+		pf.Remove("furious_furuncle", "grumpy")
+
+		Expect(s.Generation()).To(BeNumerically("<", pf.Generation()))
+		Expect(s.AllContainers()).To(ConsistOf(
+			HaveField("Name", "furious_furuncle"),
+			And(HaveField("Name", "pompous_paperboard"), HaveField("Paused", false))))
+		Expect(pf.Snapshot().AllContainers()).To(ConsistOf(
+			HaveField("Name", "murky_moby"),
+			And(HaveField("Name", "pompous_paperboard"), HaveField("Paused", true))))
+	})
+
+})
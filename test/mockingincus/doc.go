@@ -0,0 +1,11 @@
+/*
+Package mockingincus is a very minimalist mock Incus service, serving just
+enough of the Incus REST API over a unix socket for unit tests of the Incus
+engine client and watcher: server information, listing and getting instances
+including their states, and the lifecycle event websocket.
+
+The mocked instances are not created and destroyed using the Incus API but
+instead using AddInstance, StopInstance, PauseInstance, ResumeInstance, and
+RestartInstance, which additionally emit the corresponding lifecycle events.
+*/
+package mockingincus
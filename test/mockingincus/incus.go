@@ -0,0 +1,342 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockingincus
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// MockedInstance specifies the few instance properties mocked.
+type MockedInstance struct {
+	Name    string
+	Project string // Incus project; empty means the "default" project.
+	Type    string // instance type; empty means "container".
+	PID     int
+	Frozen  bool
+	Config  map[string]string
+}
+
+// key returns the instance's key in "project/name" format.
+func (i MockedInstance) key() string {
+	return project(i.Project) + "/" + i.Name
+}
+
+// project returns the specified project name, defaulting to "default".
+func project(name string) string {
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// event is a mocked Incus lifecycle event.
+type event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Project   string    `json:"project"`
+	Metadata  struct {
+		Action string `json:"action"`
+		Source string `json:"source"`
+	} `json:"metadata"`
+}
+
+// MockingIncus is a mock Incus service serving the Incus REST API over a unix
+// socket.
+type MockingIncus struct {
+	mux       sync.Mutex
+	instances map[string]MockedInstance // mocked instances by "project/name".
+	subs      map[chan event]struct{}   // event subscribers.
+
+	dir    string
+	server *httptest.Server
+	done   chan struct{} // closed when closing the mock service.
+	once   sync.Once
+}
+
+// New returns a new mock Incus service, already serving the Incus API on a
+// unix socket in a temporary directory.
+func New() (*MockingIncus, error) {
+	dir, err := os.MkdirTemp("", "mockingincus-")
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.Listen("unix", filepath.Join(dir, "unix.socket"))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	mi := &MockingIncus{
+		instances: map[string]MockedInstance{},
+		subs:      map[chan event]struct{}{},
+		dir:       dir,
+		done:      make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /1.0", mi.serverInfo)
+	mux.HandleFunc("GET /1.0/instances", mi.list)
+	mux.HandleFunc("GET /1.0/instances/{name}", mi.instance)
+	mux.HandleFunc("GET /1.0/instances/{name}/state", mi.state)
+	mux.Handle("GET /1.0/events", websocket.Handler(mi.events))
+	mi.server = httptest.NewUnstartedServer(mux)
+	_ = mi.server.Listener.Close()
+	mi.server.Listener = l
+	mi.server.Start()
+	return mi, nil
+}
+
+// Socket returns the path of the API unix socket of this mock service.
+func (mi *MockingIncus) Socket() string {
+	return filepath.Join(mi.dir, "unix.socket")
+}
+
+// Close the mock service, ending any event websockets. Close is idempotent.
+func (mi *MockingIncus) Close() {
+	mi.once.Do(func() {
+		close(mi.done)
+		mi.server.Close()
+		_ = os.RemoveAll(mi.dir)
+	})
+}
+
+// Subscribers returns the number of event websocket subscribers.
+func (mi *MockingIncus) Subscribers() int {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	return len(mi.subs)
+}
+
+// AddInstance adds an instance and emits an "instance-started" event.
+func (mi *MockingIncus) AddInstance(instance MockedInstance) {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	mi.instances[instance.key()] = instance
+	mi.emit("instance-started", instance)
+}
+
+// StopInstance removes an instance and emits an "instance-stopped" event.
+func (mi *MockingIncus) StopInstance(projectname, name string) {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	instance, ok := mi.instances[project(projectname)+"/"+name]
+	if !ok {
+		return
+	}
+	delete(mi.instances, instance.key())
+	mi.emit("instance-stopped", instance)
+}
+
+// RestartInstance changes the PID of an instance and emits an
+// "instance-restarted" event.
+func (mi *MockingIncus) RestartInstance(projectname, name string, pid int) {
+	mi.update(projectname, name, "instance-restarted", func(instance *MockedInstance) {
+		instance.PID = pid
+		instance.Frozen = false
+	})
+}
+
+// PauseInstance freezes an instance and emits an "instance-paused" event.
+func (mi *MockingIncus) PauseInstance(projectname, name string) {
+	mi.update(projectname, name, "instance-paused", func(instance *MockedInstance) {
+		instance.Frozen = true
+	})
+}
+
+// ResumeInstance thaws an instance and emits an "instance-resumed" event.
+func (mi *MockingIncus) ResumeInstance(projectname, name string) {
+	mi.update(projectname, name, "instance-resumed", func(instance *MockedInstance) {
+		instance.Frozen = false
+	})
+}
+
+func (mi *MockingIncus) update(projectname, name, action string, fn func(*MockedInstance)) {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	instance, ok := mi.instances[project(projectname)+"/"+name]
+	if !ok {
+		return
+	}
+	fn(&instance)
+	mi.instances[instance.key()] = instance
+	mi.emit(action, instance)
+}
+
+// emit a lifecycle event to all subscribers; the caller must hold the lock.
+func (mi *MockingIncus) emit(action string, instance MockedInstance) {
+	ev := event{Type: "lifecycle", Timestamp: time.Now(), Project: project(instance.Project)}
+	ev.Metadata.Action = action
+	ev.Metadata.Source = "/1.0/instances/" + url.PathEscape(instance.Name)
+	if instance.Project != "" && instance.Project != "default" {
+		ev.Metadata.Source += "?project=" + url.QueryEscape(instance.Project)
+	}
+	for sub := range mi.subs {
+		select {
+		case sub <- ev:
+		default: // drop events for slow subscribers.
+		}
+	}
+}
+
+// reply with a synchronous response carrying the specified metadata.
+func reply(w http.ResponseWriter, metadata any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":        "sync",
+		"status":      "Success",
+		"status_code": http.StatusOK,
+		"metadata":    metadata,
+	})
+}
+
+// notFound replies with an Incus-style 404 error response.
+func notFound(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":       "error",
+		"error":      "Instance not found",
+		"error_code": http.StatusNotFound,
+	})
+}
+
+// representation returns the API representation of the specified instance,
+// optionally including its state.
+func representation(instance MockedInstance, withstate bool) map[string]any {
+	typ := instance.Type
+	if typ == "" {
+		typ = "container"
+	}
+	repr := map[string]any{
+		"name":    instance.Name,
+		"project": project(instance.Project),
+		"type":    typ,
+		"status":  status(instance),
+		"config":  instance.Config,
+	}
+	if withstate {
+		repr["state"] = stateOf(instance)
+	}
+	return repr
+}
+
+func status(instance MockedInstance) string {
+	if instance.Frozen {
+		return "Frozen"
+	}
+	return "Running"
+}
+
+func stateOf(instance MockedInstance) map[string]any {
+	return map[string]any{
+		"status": status(instance),
+		"pid":    instance.PID,
+	}
+}
+
+func (mi *MockingIncus) serverInfo(w http.ResponseWriter, _ *http.Request) {
+	reply(w, map[string]any{
+		"environment": map[string]any{
+			"server":         "incus",
+			"server_name":    "mockinghost",
+			"server_version": "6.0.0",
+			"server_pid":     os.Getpid(),
+		},
+	})
+}
+
+func (mi *MockingIncus) list(w http.ResponseWriter, _ *http.Request) {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	instances := []map[string]any{}
+	for _, instance := range mi.instances {
+		instances = append(instances, representation(instance, true))
+	}
+	reply(w, instances)
+}
+
+// lookup the instance referenced by the request; the caller must hold the
+// lock.
+func (mi *MockingIncus) lookup(r *http.Request) (MockedInstance, bool) {
+	instance, ok := mi.instances[project(r.URL.Query().Get("project"))+"/"+r.PathValue("name")]
+	return instance, ok
+}
+
+func (mi *MockingIncus) instance(w http.ResponseWriter, r *http.Request) {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	instance, ok := mi.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+	reply(w, representation(instance, false))
+}
+
+func (mi *MockingIncus) state(w http.ResponseWriter, r *http.Request) {
+	mi.mux.Lock()
+	defer mi.mux.Unlock()
+	instance, ok := mi.lookup(r)
+	if !ok {
+		notFound(w)
+		return
+	}
+	reply(w, stateOf(instance))
+}
+
+func (mi *MockingIncus) events(ws *websocket.Conn) {
+	sub := make(chan event, 16)
+	mi.mux.Lock()
+	mi.subs[sub] = struct{}{}
+	mi.mux.Unlock()
+	defer func() {
+		mi.mux.Lock()
+		delete(mi.subs, sub)
+		mi.mux.Unlock()
+	}()
+	// Detect the client going away by reading (and discarding) anything it
+	// might send; closing the websocket ends the reader.
+	gone := make(chan struct{})
+	go func() {
+		defer close(gone)
+		var msg []byte
+		for websocket.Message.Receive(ws, &msg) == nil {
+		}
+	}()
+	defer func() {
+		_ = ws.Close()
+		<-gone
+	}()
+	for {
+		select {
+		case <-gone:
+			return
+		case <-mi.done:
+			return
+		case ev := <-sub:
+			if err := websocket.JSON.Send(ws, ev); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockingincus
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("mocking Incus", func() {
+
+	BeforeEach(func() {
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+		})
+	})
+
+	It("serves mocked instances and cleans up", func() {
+		mi := Successful(New())
+		Expect(mi.Socket()).To(BeAnExistingFile())
+
+		mi.AddInstance(MockedInstance{Name: "foo", Project: "bar", PID: 42})
+		mi.PauseInstance("bar", "foo")
+		get := func(path string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			mi.server.Config.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			Expect(json.Valid(w.Body.Bytes())).To(BeTrue())
+			return w
+		}
+		Expect(get("/1.0/instances/foo/state?project=bar").Body.String()).To(ContainSubstring(`"status":"Frozen"`))
+		Expect(get("/1.0/instances/foo").Code).To(Equal(http.StatusNotFound))
+		Expect(get("/1.0/instances/foo/state").Code).To(Equal(http.StatusNotFound))
+
+		mi.ResumeInstance("bar", "foo")
+		mi.RestartInstance("bar", "foo", 43)
+		Expect(get("/1.0/instances").Body.String()).To(And(
+			ContainSubstring(`"status":"Running"`),
+			ContainSubstring(`"pid":43`)))
+		mi.StopInstance("bar", "foo")
+		mi.StopInstance("bar", "foo")
+		mi.PauseInstance("bar", "foo")
+
+		mi.Close()
+		mi.Close()
+		_, err := os.Stat(mi.dir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+})
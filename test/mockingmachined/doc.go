@@ -0,0 +1,16 @@
+/*
+Package mockingmachined is a very minimalist mock systemd-machined service for
+unit tests, serving just enough of the org.freedesktop.machine1 D-Bus API on a
+private D-Bus message bus: listing and getting machines, the properties of
+machine objects, as well as the MachineNew and MachineRemoved signals. It
+additionally serves the systemd version property.
+
+The private message bus is a dbus-daemon process started especially for each
+mock service; if dbus-daemon isn't installed, New fails with
+[ErrNoDBusDaemon].
+
+The mocked machines are not registered and terminated using the D-Bus API but
+instead using AddMachine and RemoveMachine, which additionally emit the
+corresponding signals.
+*/
+package mockingmachined
@@ -0,0 +1,297 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockingmachined
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ErrNoDBusDaemon signals that the dbus-daemon binary needed for running a
+// private D-Bus message bus is unavailable.
+var ErrNoDBusDaemon = errors.New("dbus-daemon not available")
+
+// Version is the mocked systemd version.
+const Version = "257"
+
+const (
+	machinedService = "org.freedesktop.machine1"
+	managerPath     = dbus.ObjectPath("/org/freedesktop/machine1")
+	managerIface    = "org.freedesktop.machine1.Manager"
+	machineIface    = "org.freedesktop.machine1.Machine"
+	systemdService  = "org.freedesktop.systemd1"
+	systemdPath     = dbus.ObjectPath("/org/freedesktop/systemd1")
+	systemdIface    = "org.freedesktop.systemd1.Manager"
+	propertiesIface = "org.freedesktop.DBus.Properties"
+)
+
+// busconfig is the configuration of the private D-Bus message bus, allowing
+// everything.
+const busconfig = `<!DOCTYPE busconfig PUBLIC "-//freedesktop//DTD D-Bus Bus Configuration 1.0//EN"
+ "http://www.freedesktop.org/standards/dbus/1.0/busconfig.dtd">
+<busconfig>
+  <type>session</type>
+  <listen>unix:dir=%s</listen>
+  <auth>EXTERNAL</auth>
+  <policy context="default">
+    <allow send_destination="*" eavesdrop="true"/>
+    <allow eavesdrop="true"/>
+    <allow own="*"/>
+  </policy>
+</busconfig>
+`
+
+// MockedMachine specifies the few machine properties mocked.
+type MockedMachine struct {
+	Name          string
+	Class         string // "container", "vm", or "host"; empty means "container".
+	Service       string
+	Unit          string
+	Leader        uint32
+	RootDirectory string
+}
+
+// class returns the machine's class, defaulting to "container".
+func (m MockedMachine) class() string {
+	if m.Class == "" {
+		return "container"
+	}
+	return m.Class
+}
+
+// MockingMachined is a mock systemd-machined service on a private D-Bus
+// message bus.
+type MockingMachined struct {
+	mux      sync.Mutex
+	machines map[string]MockedMachine // mocked machines by name.
+
+	dir     string
+	address string
+	daemon  *exec.Cmd
+	conn    *dbus.Conn
+	once    sync.Once
+}
+
+// New returns a new mock systemd-machined service, already serving on a newly
+// started private D-Bus message bus.
+func New() (mm *MockingMachined, err error) {
+	daemonpath, err := exec.LookPath("dbus-daemon")
+	if err != nil {
+		return nil, ErrNoDBusDaemon
+	}
+	mm = &MockingMachined{machines: map[string]MockedMachine{}}
+	mm.dir, err = os.MkdirTemp("", "mockingmachined-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			mm.Close()
+			mm = nil
+		}
+	}()
+	confpath := filepath.Join(mm.dir, "bus.conf")
+	if err = os.WriteFile(confpath, []byte(fmt.Sprintf(busconfig, mm.dir)), 0o600); err != nil {
+		return
+	}
+	mm.daemon = exec.Command(daemonpath,
+		"--config-file="+confpath, "--nofork", "--nopidfile", "--print-address=1")
+	stdout, err := mm.daemon.StdoutPipe()
+	if err != nil {
+		return
+	}
+	if err = mm.daemon.Start(); err != nil {
+		mm.daemon = nil
+		return
+	}
+	mm.address, err = bufio.NewReader(stdout).ReadString('\n')
+	_ = stdout.Close()
+	if err != nil {
+		return
+	}
+	mm.address = strings.TrimSpace(mm.address)
+	if mm.conn, err = dbus.Connect(mm.address); err != nil {
+		return
+	}
+	for _, name := range []string{machinedService, systemdService} {
+		var reply dbus.RequestNameReply
+		reply, err = mm.conn.RequestName(name, dbus.NameFlagDoNotQueue)
+		if err != nil {
+			return
+		}
+		if reply != dbus.RequestNameReplyPrimaryOwner {
+			err = fmt.Errorf("cannot own D-Bus name %q", name)
+			return
+		}
+	}
+	if err = mm.conn.Export(manager{mm: mm}, managerPath, managerIface); err != nil {
+		return
+	}
+	err = mm.conn.Export(properties{
+		iface: systemdIface,
+		props: func() map[string]dbus.Variant {
+			return map[string]dbus.Variant{"Version": dbus.MakeVariant(Version)}
+		},
+	}, systemdPath, propertiesIface)
+	return
+}
+
+// Address returns the D-Bus address of the private message bus.
+func (mm *MockingMachined) Address() string { return mm.address }
+
+// Close the mock service, terminating its private D-Bus message bus. Close is
+// idempotent.
+func (mm *MockingMachined) Close() {
+	mm.once.Do(func() {
+		if mm.conn != nil {
+			_ = mm.conn.Close()
+		}
+		if mm.daemon != nil {
+			_ = mm.daemon.Process.Kill()
+			_ = mm.daemon.Wait()
+		}
+		_ = os.RemoveAll(mm.dir)
+	})
+}
+
+// AddMachine registers a machine and emits a MachineNew signal.
+func (mm *MockingMachined) AddMachine(machine MockedMachine) {
+	mm.mux.Lock()
+	mm.machines[machine.Name] = machine
+	mm.mux.Unlock()
+	path := MachinePath(machine.Name)
+	_ = mm.conn.Export(properties{
+		iface: machineIface,
+		props: func() map[string]dbus.Variant {
+			return map[string]dbus.Variant{
+				"Name":          dbus.MakeVariant(machine.Name),
+				"Class":         dbus.MakeVariant(machine.class()),
+				"Service":       dbus.MakeVariant(machine.Service),
+				"Unit":          dbus.MakeVariant(machine.Unit),
+				"Leader":        dbus.MakeVariant(machine.Leader),
+				"RootDirectory": dbus.MakeVariant(machine.RootDirectory),
+				"State":         dbus.MakeVariant("running"),
+			}
+		},
+	}, path, propertiesIface)
+	_ = mm.conn.Emit(managerPath, managerIface+".MachineNew", machine.Name, path)
+}
+
+// RemoveMachine terminates a machine and emits a MachineRemoved signal.
+func (mm *MockingMachined) RemoveMachine(name string) {
+	mm.mux.Lock()
+	_, ok := mm.machines[name]
+	delete(mm.machines, name)
+	mm.mux.Unlock()
+	if !ok {
+		return
+	}
+	path := MachinePath(name)
+	_ = mm.conn.Export(nil, path, propertiesIface)
+	_ = mm.conn.Emit(managerPath, managerIface+".MachineRemoved", name, path)
+}
+
+// MachinePath returns the D-Bus object path of the named machine, escaping the
+// name in the same way as systemd does.
+func MachinePath(name string) dbus.ObjectPath {
+	var b strings.Builder
+	for idx := 0; idx < len(name); idx++ {
+		c := name[idx]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "_%02x", c)
+	}
+	return dbus.ObjectPath(string(managerPath) + "/machine/" + b.String())
+}
+
+// listedMachine is a machine as listed by the manager's ListMachines method.
+type listedMachine struct {
+	Name    string
+	Class   string
+	Service string
+	Path    dbus.ObjectPath
+}
+
+// manager implements the mocked methods of the machined manager.
+type manager struct {
+	mm *MockingMachined
+}
+
+// ListMachines returns the registered machines.
+func (m manager) ListMachines() ([]listedMachine, *dbus.Error) {
+	m.mm.mux.Lock()
+	defer m.mm.mux.Unlock()
+	machines := []listedMachine{}
+	for _, machine := range m.mm.machines {
+		machines = append(machines, listedMachine{
+			Name:    machine.Name,
+			Class:   machine.class(),
+			Service: machine.Service,
+			Path:    MachinePath(machine.Name),
+		})
+	}
+	return machines, nil
+}
+
+// GetMachine returns the object path of the named machine.
+func (m manager) GetMachine(name string) (dbus.ObjectPath, *dbus.Error) {
+	m.mm.mux.Lock()
+	defer m.mm.mux.Unlock()
+	if _, ok := m.mm.machines[name]; !ok {
+		return "", dbus.NewError("org.freedesktop.machine1.NoSuchMachine",
+			[]any{fmt.Sprintf("No machine '%s' known", name)})
+	}
+	return MachinePath(name), nil
+}
+
+// properties implements the org.freedesktop.DBus.Properties interface for a
+// single interface with read-only properties.
+type properties struct {
+	iface string
+	props func() map[string]dbus.Variant
+}
+
+// Get returns the value of the specified property.
+func (p properties) Get(iface, prop string) (dbus.Variant, *dbus.Error) {
+	if iface != p.iface {
+		err := dbus.MakeUnknownInterfaceError(iface)
+		return dbus.Variant{}, &err
+	}
+	value, ok := p.props()[prop]
+	if !ok {
+		return dbus.Variant{}, dbus.NewError("org.freedesktop.DBus.Error.UnknownProperty",
+			[]any{fmt.Sprintf("unknown property %q", prop)})
+	}
+	return value, nil
+}
+
+// GetAll returns all properties.
+func (p properties) GetAll(iface string) (map[string]dbus.Variant, *dbus.Error) {
+	if iface != p.iface {
+		err := dbus.MakeUnknownInterfaceError(iface)
+		return nil, &err
+	}
+	return p.props(), nil
+}
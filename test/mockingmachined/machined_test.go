@@ -0,0 +1,78 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockingmachined
+
+import (
+	"errors"
+	"os"
+
+	"github.com/godbus/dbus/v5"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("mocking machined", func() {
+
+	BeforeEach(func() {
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+		})
+	})
+
+	It("escapes machine names", func() {
+		Expect(MachinePath("foo-bar.baz")).To(Equal(
+			dbus.ObjectPath("/org/freedesktop/machine1/machine/foo_2dbar_2ebaz")))
+	})
+
+	It("serves mocked machines and cleans up", func() {
+		mm, err := New()
+		if errors.Is(err, ErrNoDBusDaemon) {
+			Skip("needs dbus-daemon")
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		conn := Successful(dbus.Connect(mm.Address()))
+		defer func() { _ = conn.Close() }()
+
+		mm.AddMachine(MockedMachine{Name: "foo", Leader: 42})
+		mgr := conn.Object(machinedService, managerPath)
+		var path dbus.ObjectPath
+		Expect(mgr.Call(managerIface+".GetMachine", 0, "foo").Store(&path)).To(Succeed())
+		Expect(path).To(Equal(MachinePath("foo")))
+		var leader dbus.Variant
+		Expect(conn.Object(machinedService, path).
+			Call(propertiesIface+".Get", 0, machineIface, "Leader").Store(&leader)).To(Succeed())
+		Expect(leader.Value()).To(Equal(uint32(42)))
+		Expect(conn.Object(machinedService, path).
+			Call(propertiesIface+".Get", 0, machineIface, "Foo").Err).To(HaveOccurred())
+		Expect(conn.Object(machinedService, path).
+			Call(propertiesIface+".Get", 0, "org.example.Foo", "Leader").Err).To(HaveOccurred())
+		Expect(conn.Object(machinedService, path).
+			Call(propertiesIface+".GetAll", 0, "org.example.Foo").Err).To(HaveOccurred())
+
+		mm.RemoveMachine("foo")
+		mm.RemoveMachine("foo")
+		Expect(mgr.Call(managerIface+".GetMachine", 0, "foo").Err).To(HaveOccurred())
+
+		mm.Close()
+		mm.Close()
+		_, err = os.Stat(mm.dir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+})
@@ -17,6 +17,7 @@ package mockingmoby
 import (
 	"context"
 	"maps"
+	"strconv"
 	"sync"
 
 	"github.com/moby/moby/api/types/events"
@@ -81,9 +82,11 @@ func isCtxCancelled(ctx context.Context) error {
 // if the container is in running or paused states.
 func (mm *MockingMoby) AddContainer(c MockedContainer) {
 	mm.mux.Lock()
-	defer mm.mux.Unlock()
 	mm.containers[c.ID] = c
 	mm.names[c.Name] = c.ID
+	// make sure to not hold the lock while emitting the event, as otherwise
+	// inspecting containers in response to earlier events would block.
+	mm.mux.Unlock()
 	switch c.Status {
 	case MockedRunning, MockedPaused:
 		mm.containerEvent("start", events.Actor{
@@ -109,7 +112,7 @@ func (mm *MockingMoby) StopContainer(nameorid string) {
 		case MockedRunning, MockedPaused:
 			mm.containerEvent("die", events.Actor{
 				ID:         c.ID,
-				Attributes: MockAttributes(c),
+				Attributes: dieAttributes(c),
 			})
 		}
 	}
@@ -127,7 +130,7 @@ func (mm *MockingMoby) RemoveContainer(nameorid string) {
 		case MockedRunning, MockedPaused:
 			mm.containerEvent("die", events.Actor{
 				ID:         c.ID,
-				Attributes: MockAttributes(c),
+				Attributes: dieAttributes(c),
 			})
 		}
 	}
@@ -171,6 +174,31 @@ func (mm *MockingMoby) UnpauseContainer(nameorid string) {
 	}
 }
 
+// SetContainerHealth sets the health status of a container, such as
+// "healthy", and emits a container health_status event.
+func (mm *MockingMoby) SetContainerHealth(nameorid string, health string) {
+	if c, ok := mm.lookup(nameorid); ok {
+		mm.mux.Lock()
+		c.Health = health
+		mm.containers[c.ID] = c
+		mm.mux.Unlock()
+		mm.containerEvent("health_status: "+health, events.Actor{
+			ID:         c.ID,
+			Attributes: MockAttributes(c),
+		})
+	}
+}
+
+// EmitEvent emits a container event with the specified action, such as
+// "create" or "destroy", for the specified mocked container without changing
+// any mocked container state.
+func (mm *MockingMoby) EmitEvent(action string, c MockedContainer) {
+	mm.containerEvent(action, events.Actor{
+		ID:         c.ID,
+		Attributes: MockAttributes(c),
+	})
+}
+
 // lookup returns a mocked container identified either by ID or name. If not
 // found, returns false.
 func (mm *MockingMoby) lookup(nameorid string) (MockedContainer, bool) {
@@ -185,6 +213,14 @@ func (mm *MockingMoby) lookup(nameorid string) (MockedContainer, bool) {
 	return c, ok
 }
 
+// dieAttributes returns the mocked attributes map of a "die" event for the
+// specified mock container, additionally including the container's exit code.
+func dieAttributes(c MockedContainer) map[string]string {
+	attrs := MockAttributes(c)
+	attrs["exitCode"] = strconv.Itoa(c.ExitCode)
+	return attrs
+}
+
 // MockAttributes returns a mocked attributes map for the specified mock
 // container, based on the container's labels and additional attributes (namely,
 // the container name as opposed to its ID). The attributes map is suitable for
@@ -16,6 +16,7 @@ package mockingmoby
 
 import (
 	"context"
+	"time"
 
 	"github.com/containerd/errdefs"
 	"github.com/moby/moby/api/types/container"
@@ -37,15 +38,22 @@ func (mm *MockingMoby) ContainerInspect(ctx context.Context, nameorid string, op
 	if !ok {
 		return client.ContainerInspectResult{}, errwrap(errdefs.ErrNotFound, "no such container %q", nameorid)
 	}
+	var health *container.Health
+	if c.Health != "" {
+		health = &container.Health{Status: container.HealthStatus(c.Health)}
+	}
 	return client.ContainerInspectResult{
 		Container: container.InspectResponse{
 			ID:   c.ID,
 			Name: "/" + c.Name,
 			State: &container.State{
-				Status:  MockedContainerStates[c.Status],
-				Running: c.Status == MockedRunning || c.Status == MockedPaused,
-				Paused:  c.Status == MockedPaused,
-				Pid:     c.PID,
+				Status:    MockedContainerStates[c.Status],
+				Running:   c.Status == MockedRunning || c.Status == MockedPaused,
+				Paused:    c.Status == MockedPaused,
+				Pid:       c.PID,
+				OOMKilled: c.OOMKilled && c.Status != MockedRunning && c.Status != MockedPaused,
+				StartedAt: c.StartedAt.Format(time.RFC3339Nano),
+				Health:    health,
 			},
 			Config: &container.Config{
 				Labels: c.Labels,
@@ -17,6 +17,7 @@ package mockingmoby
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/client"
@@ -81,11 +82,14 @@ func (mm *MockingMoby) containerEvent(action string, actor events.Actor) {
 	evs := mm.events
 	mm.emux.Unlock()
 	if evs != nil {
+		now := time.Now()
 		evs <- events.Message{
-			Type:   events.ContainerEventType,
-			Action: events.Action(action),
-			Actor:  actor,
-			Scope:  "local",
+			Type:     events.ContainerEventType,
+			Action:   events.Action(action),
+			Actor:    actor,
+			Scope:    "local",
+			Time:     now.Unix(),
+			TimeNano: now.UnixNano(),
 		}
 	}
 }
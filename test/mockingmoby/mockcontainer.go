@@ -14,7 +14,11 @@
 
 package mockingmoby
 
-import "github.com/moby/moby/api/types/container"
+import (
+	"time"
+
+	"github.com/moby/moby/api/types/container"
+)
 
 // MockedContainerState is a compressed, only-essentials, no-bulls version of
 // Docker's types.ContainerStatus.
@@ -60,4 +64,9 @@ type MockedContainer struct {
 	Status MockedContainerState // container status (without any thrills)
 	PID    int                  // PID of initial container process if container is "alive"
 	Labels map[string]string    // container labels
+
+	StartedAt time.Time // optional start time of container
+	ExitCode  int       // exit code reported when the container dies
+	OOMKilled bool      // OOM-killed state reported when inspecting a dead container
+	Health    string    // optional health status, such as "healthy"
 }
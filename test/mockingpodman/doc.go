@@ -0,0 +1,11 @@
+/*
+Package mockingpodman is a very minimalist mock Podman service, serving just
+enough of Podman's libpod REST API over a unix socket for unit tests of the
+Podman engine client and watcher: system information, listing and inspecting
+containers, inspecting pods, and streaming container events.
+
+The mocked containers and pods are not created and destroyed using the libpod
+API but instead using AddPod, AddContainer, StopContainer, PauseContainer, and
+UnpauseContainer, which additionally emit the corresponding container events.
+*/
+package mockingpodman
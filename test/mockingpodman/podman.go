@@ -0,0 +1,335 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockingpodman
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// APIPrefix is the path prefix of the mocked libpod API.
+const APIPrefix = "/v4.0.0/libpod"
+
+// MockedContainer specifies the few container properties mocked.
+type MockedContainer struct {
+	ID         string
+	Name       string
+	PID        int
+	Paused     bool
+	Labels     map[string]string
+	Pod        string // pod ID, if any.
+	Infra      bool   // pod infrastructure container.
+	Privileged bool
+	StartedAt  time.Time // start time, if any.
+	ExitCode   int       // exit code reported when the container dies.
+}
+
+// MockedPod specifies the few pod properties mocked.
+type MockedPod struct {
+	ID   string
+	Name string
+}
+
+// event is a mocked libpod event.
+type event struct {
+	Type   string `json:"Type"`
+	Action string `json:"Action"`
+	Actor  struct {
+		ID         string            `json:"ID"`
+		Attributes map[string]string `json:"Attributes"`
+	} `json:"Actor"`
+	TimeNano int64 `json:"timeNano"`
+}
+
+// MockingPodman is a mock Podman service serving the libpod API over a unix
+// socket.
+type MockingPodman struct {
+	mux        sync.Mutex
+	containers map[string]MockedContainer // mocked containers by ID.
+	pods       map[string]MockedPod       // mocked pods by ID.
+	subs       map[chan event]struct{}    // event subscribers.
+
+	dir    string
+	server *httptest.Server
+	done   chan struct{} // closed when closing the mock service.
+	once   sync.Once
+}
+
+// New returns a new mock Podman service, already serving the libpod API on a
+// unix socket in a temporary directory.
+func New() (*MockingPodman, error) {
+	dir, err := os.MkdirTemp("", "mockingpodman-")
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.Listen("unix", filepath.Join(dir, "podman.sock"))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	mp := &MockingPodman{
+		containers: map[string]MockedContainer{},
+		pods:       map[string]MockedPod{},
+		subs:       map[chan event]struct{}{},
+		dir:        dir,
+		done:       make(chan struct{}),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPrefix+"/info", mp.info)
+	mux.HandleFunc("GET "+APIPrefix+"/containers/json", mp.list)
+	mux.HandleFunc("GET "+APIPrefix+"/containers/{nameorid}/json", mp.inspect)
+	mux.HandleFunc("GET "+APIPrefix+"/pods/{nameorid}/json", mp.inspectPod)
+	mux.HandleFunc("GET "+APIPrefix+"/events", mp.events)
+	mp.server = httptest.NewUnstartedServer(mux)
+	_ = mp.server.Listener.Close()
+	mp.server.Listener = l
+	mp.server.Start()
+	return mp, nil
+}
+
+// Host returns the API endpoint of this mock service in "unix:///path"
+// format.
+func (mp *MockingPodman) Host() string {
+	return "unix://" + filepath.Join(mp.dir, "podman.sock")
+}
+
+// Close the mock service, ending any event streams. Close is idempotent.
+func (mp *MockingPodman) Close() {
+	mp.once.Do(func() {
+		close(mp.done)
+		mp.server.Close()
+		_ = os.RemoveAll(mp.dir)
+	})
+}
+
+// Subscribers returns the number of event stream subscribers.
+func (mp *MockingPodman) Subscribers() int {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	return len(mp.subs)
+}
+
+// AddPod adds a pod.
+func (mp *MockingPodman) AddPod(pod MockedPod) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	mp.pods[pod.ID] = pod
+}
+
+// AddContainer adds a container and emits a "start" event.
+func (mp *MockingPodman) AddContainer(cntr MockedContainer) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	mp.containers[cntr.ID] = cntr
+	mp.emit("start", cntr)
+}
+
+// StopContainer removes a container and emits a "died" event.
+func (mp *MockingPodman) StopContainer(nameorid string) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	cntr, ok := mp.lookup(nameorid)
+	if !ok {
+		return
+	}
+	delete(mp.containers, cntr.ID)
+	mp.emit("died", cntr)
+}
+
+// EmitEvent emits an event with the specified action, such as "create" or
+// "remove", for the specified container without changing any container
+// state.
+func (mp *MockingPodman) EmitEvent(action string, cntr MockedContainer) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	mp.emit(action, cntr)
+}
+
+// PauseContainer pauses a container and emits a "pause" event.
+func (mp *MockingPodman) PauseContainer(nameorid string) {
+	mp.setPaused(nameorid, true, "pause")
+}
+
+// UnpauseContainer unpauses a container and emits an "unpause" event.
+func (mp *MockingPodman) UnpauseContainer(nameorid string) {
+	mp.setPaused(nameorid, false, "unpause")
+}
+
+func (mp *MockingPodman) setPaused(nameorid string, paused bool, action string) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	cntr, ok := mp.lookup(nameorid)
+	if !ok {
+		return
+	}
+	cntr.Paused = paused
+	mp.containers[cntr.ID] = cntr
+	mp.emit(action, cntr)
+}
+
+// lookup a container by ID or name; the caller must hold the lock.
+func (mp *MockingPodman) lookup(nameorid string) (MockedContainer, bool) {
+	if cntr, ok := mp.containers[nameorid]; ok {
+		return cntr, true
+	}
+	for _, cntr := range mp.containers {
+		if cntr.Name == nameorid {
+			return cntr, true
+		}
+	}
+	return MockedContainer{}, false
+}
+
+// emit a container event to all subscribers; the caller must hold the lock.
+func (mp *MockingPodman) emit(action string, cntr MockedContainer) {
+	ev := event{Type: "container", Action: action, TimeNano: time.Now().UnixNano()}
+	ev.Actor.ID = cntr.ID
+	ev.Actor.Attributes = map[string]string{"name": cntr.Name, "podId": cntr.Pod}
+	if action == "died" {
+		ev.Actor.Attributes["containerExitCode"] = strconv.Itoa(cntr.ExitCode)
+	}
+	for sub := range mp.subs {
+		select {
+		case sub <- ev:
+		default: // drop events for slow subscribers.
+		}
+	}
+}
+
+// reply with the JSON representation of the specified value.
+func reply(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// notFound replies with a libpod-style 404 error.
+func notFound(w http.ResponseWriter, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"cause":    "no such container",
+		"message":  msg,
+		"response": http.StatusNotFound,
+	})
+}
+
+func (mp *MockingPodman) info(w http.ResponseWriter, _ *http.Request) {
+	reply(w, map[string]any{
+		"host":    map[string]any{"hostname": "mockinghost"},
+		"store":   map[string]any{"graphRoot": "/var/lib/containers/storage"},
+		"version": map[string]any{"APIVersion": "4.9.3", "Version": "4.9.3"},
+	})
+}
+
+func (mp *MockingPodman) list(w http.ResponseWriter, _ *http.Request) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	cntrs := []map[string]any{}
+	for _, cntr := range mp.containers {
+		state := "running"
+		if cntr.Paused {
+			state = "paused"
+		}
+		cntrs = append(cntrs, map[string]any{
+			"Id":    cntr.ID,
+			"Names": []string{cntr.Name},
+			"State": state,
+		})
+	}
+	reply(w, cntrs)
+}
+
+func (mp *MockingPodman) inspect(w http.ResponseWriter, r *http.Request) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	cntr, ok := mp.lookup(r.PathValue("nameorid"))
+	if !ok {
+		notFound(w, "no container with name or ID \""+r.PathValue("nameorid")+"\" found")
+		return
+	}
+	status := "running"
+	if cntr.Paused {
+		status = "paused"
+	}
+	reply(w, map[string]any{
+		"Id":   cntr.ID,
+		"Name": cntr.Name,
+		"Pod":  cntr.Pod,
+		"State": map[string]any{
+			"Status":    status,
+			"Running":   !cntr.Paused,
+			"Paused":    cntr.Paused,
+			"Pid":       cntr.PID,
+			"StartedAt": cntr.StartedAt,
+		},
+		"Config":     map[string]any{"Labels": cntr.Labels},
+		"HostConfig": map[string]any{"Privileged": cntr.Privileged},
+		"IsInfra":    cntr.Infra,
+	})
+}
+
+func (mp *MockingPodman) inspectPod(w http.ResponseWriter, r *http.Request) {
+	mp.mux.Lock()
+	defer mp.mux.Unlock()
+	nameorid := r.PathValue("nameorid")
+	for _, pod := range mp.pods {
+		if pod.ID == nameorid || pod.Name == nameorid {
+			reply(w, map[string]any{"Id": pod.ID, "Name": pod.Name})
+			return
+		}
+	}
+	notFound(w, "no pod with name or ID \""+nameorid+"\" found")
+}
+
+func (mp *MockingPodman) events(w http.ResponseWriter, r *http.Request) {
+	sub := make(chan event, 16)
+	mp.mux.Lock()
+	mp.subs[sub] = struct{}{}
+	mp.mux.Unlock()
+	defer func() {
+		mp.mux.Lock()
+		delete(mp.subs, sub)
+		mp.mux.Unlock()
+	}()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-mp.done:
+			return
+		case ev := <-sub:
+			if err := enc.Encode(ev); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mockingpodman
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("mocking Podman", func() {
+
+	BeforeEach(func() {
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+		})
+	})
+
+	It("serves mocked containers and cleans up", func() {
+		mp := Successful(New())
+		sock := strings.TrimPrefix(mp.Host(), "unix://")
+		Expect(sock).To(BeAnExistingFile())
+
+		mp.AddContainer(MockedContainer{ID: "1234", Name: "foo", PID: 42})
+		mp.PauseContainer("foo")
+		get := func(path string) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			mp.server.Config.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, APIPrefix+path, nil))
+			Expect(json.Valid(w.Body.Bytes())).To(BeTrue())
+			return w
+		}
+		Expect(get("/containers/foo/json").Body.String()).To(ContainSubstring(`"Paused":true`))
+		Expect(get("/containers/bar/json").Code).To(Equal(http.StatusNotFound))
+		Expect(get("/pods/bar/json").Code).To(Equal(http.StatusNotFound))
+
+		mp.UnpauseContainer("1234")
+		Expect(get("/containers/json").Body.String()).To(ContainSubstring(`"State":"running"`))
+		mp.StopContainer("1234")
+		mp.StopContainer("1234")
+
+		mp.Close()
+		mp.Close()
+		Expect(sock).NotTo(BeAnExistingFile())
+		_, err := os.Stat(mp.dir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+})
@@ -0,0 +1,291 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/thediveo/whalewatcher/v2/watcher"
+)
+
+// Engine identifies a container engine watched as part of a [Composite].
+type Engine struct {
+	Type string // engine type, such as "docker.com".
+	ID   string // engine ID; empty if not (yet) known.
+}
+
+// String returns the engine identification in "type/ID" format.
+func (e Engine) String() string {
+	return e.Type + "/" + e.ID
+}
+
+// Event is a container lifecycle event, attributed to the engine it originates
+// from.
+type Event struct {
+	Engine Engine
+	watcher.ContainerEvent
+}
+
+// Status is the status of an individual engine watcher of a [Composite].
+type Status struct {
+	Engine  Engine
+	Watcher watcher.Watcher // the engine watcher.
+	Ready   bool            // initial synchronization has been achieved.
+	Err     error           // error returned from watching, if watching has ended.
+}
+
+// EngineError is an error returned from watching a particular container
+// engine.
+type EngineError struct {
+	Engine Engine
+	Err    error
+}
+
+// Error returns the error message, prefixed by the engine identification.
+func (e *EngineError) Error() string {
+	return fmt.Sprintf("engine %s: %s", e.Engine, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *EngineError) Unwrap() error { return e.Err }
+
+// Composite combines multiple engine watchers, offering a merged portfolio and
+// a single merged event stream.
+type Composite struct {
+	members []*member
+
+	ready      chan struct{} // ready channel signal
+	closeReady func()        // idempotent ready channel closing
+
+	eventchmux sync.Mutex
+	eventchs   []chan Event
+	closed     bool // no more events.
+}
+
+// member is an individual engine watcher of a Composite.
+type member struct {
+	ww watcher.Watcher
+
+	m   sync.Mutex
+	id  string // engine ID, if already known.
+	err error  // error returned from watching.
+}
+
+// New returns a new Composite combining the specified engine watchers. The
+// Composite takes ownership of the watchers, so closing the Composite also
+// closes the individual watchers.
+func New(watchers ...watcher.Watcher) *Composite {
+	c := &Composite{
+		ready: make(chan struct{}),
+	}
+	c.closeReady = sync.OnceFunc(func() { close(c.ready) })
+	for _, ww := range watchers {
+		mbr := &member{ww: ww}
+		c.members = append(c.members, mbr)
+		// Subscribe to the watcher's events right now, so we don't miss any
+		// events; forwarding ends when the watcher gets closed.
+		go c.forward(mbr, ww.Events())
+	}
+	return c
+}
+
+// engine returns the engine identification of this member.
+func (mbr *member) engine() Engine {
+	mbr.m.Lock()
+	defer mbr.m.Unlock()
+	return Engine{Type: mbr.ww.Type(), ID: mbr.id}
+}
+
+// resolve the engine ID of this member, unless already known.
+func (mbr *member) resolve(ctx context.Context) {
+	mbr.m.Lock()
+	known := mbr.id != ""
+	mbr.m.Unlock()
+	if known {
+		return
+	}
+	id := mbr.ww.ID(ctx)
+	mbr.m.Lock()
+	defer mbr.m.Unlock()
+	mbr.id = id
+}
+
+// Watchers returns the individual engine watchers.
+func (c *Composite) Watchers() []watcher.Watcher {
+	watchers := make([]watcher.Watcher, len(c.members))
+	for idx, mbr := range c.members {
+		watchers[idx] = mbr.ww
+	}
+	return watchers
+}
+
+// Engines returns the identifications of the engines watched.
+func (c *Composite) Engines() []Engine {
+	engines := make([]Engine, len(c.members))
+	for idx, mbr := range c.members {
+		engines[idx] = mbr.engine()
+	}
+	return engines
+}
+
+// Portfolio returns a merged portfolio, based on consistent snapshots of the
+// portfolios of the individual engine watchers.
+func (c *Composite) Portfolio() *Portfolio {
+	p := &Portfolio{}
+	for _, mbr := range c.members {
+		p.engines = append(p.engines, mbr.engine())
+		p.snapshots = append(p.snapshots, mbr.ww.Portfolio().Snapshot())
+	}
+	return p
+}
+
+// Ready returns a channel that gets closed after all engine watchers have
+// achieved their initial synchronization. Use [Composite.Status] to learn
+// about the readiness of individual engine watchers.
+func (c *Composite) Ready() <-chan struct{} {
+	return c.ready
+}
+
+// Status returns the status of the individual engine watchers.
+func (c *Composite) Status() []Status {
+	status := make([]Status, len(c.members))
+	for idx, mbr := range c.members {
+		status[idx] = Status{
+			Engine:  mbr.engine(),
+			Watcher: mbr.ww,
+			Ready:   isClosed(mbr.ww.Ready()),
+		}
+		mbr.m.Lock()
+		status[idx].Err = mbr.err
+		mbr.m.Unlock()
+	}
+	return status
+}
+
+// isClosed returns true if the specified channel has been closed.
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// Events returns a new event channel transmitting the container lifecycle
+// events of all engine watchers. It will automatically be closed when the
+// Composite is closed.
+func (c *Composite) Events() <-chan Event {
+	c.eventchmux.Lock()
+	defer c.eventchmux.Unlock()
+	evs := make(chan Event, 10)
+	if c.closed {
+		close(evs)
+		return evs
+	}
+	c.eventchs = append(c.eventchs, evs)
+	return evs
+}
+
+// forward the events from the specified member's event channel to all
+// registered event channels of this Composite, until the member's event
+// channel gets closed.
+func (c *Composite) forward(mbr *member, evs <-chan watcher.ContainerEvent) {
+	for ev := range evs {
+		c.notify(Event{Engine: mbr.engine(), ContainerEvent: ev})
+	}
+}
+
+// notify sends the specified event to all registered event channels.
+func (c *Composite) notify(ev Event) {
+	c.eventchmux.Lock()
+	defer c.eventchmux.Unlock()
+	for _, evs := range c.eventchs {
+		evs <- ev
+	}
+}
+
+// Watch all engines until the specified context gets cancelled, returning only
+// after all engine watchers have returned. Errors returned from the individual
+// engine watchers are reported as [EngineError]s, joined into a single error.
+func (c *Composite) Watch(ctx context.Context) error {
+	// The helpers waiting for the engine watchers to become ready must also
+	// give up when all engine watchers have returned without ever becoming
+	// ready.
+	helperctx, cancelhelpers := context.WithCancel(ctx)
+	var helpers sync.WaitGroup
+	var watchers sync.WaitGroup
+	for _, mbr := range c.members {
+		watchers.Go(func() {
+			mbr.resolve(ctx)
+			err := mbr.ww.Watch(ctx)
+			mbr.m.Lock()
+			defer mbr.m.Unlock()
+			mbr.err = err
+		})
+		helpers.Go(func() {
+			// Once the engine watcher has become ready, try again to resolve
+			// the engine ID if it is still unknown.
+			select {
+			case <-mbr.ww.Ready():
+				mbr.resolve(helperctx)
+			case <-helperctx.Done():
+			}
+		})
+	}
+	helpers.Go(func() {
+		for _, mbr := range c.members {
+			select {
+			case <-mbr.ww.Ready():
+			case <-helperctx.Done():
+				return
+			}
+		}
+		c.closeReady()
+	})
+	watchers.Wait()
+	cancelhelpers()
+	helpers.Wait()
+	var errs []error
+	for _, mbr := range c.members {
+		mbr.m.Lock()
+		if mbr.err != nil {
+			errs = append(errs, &EngineError{
+				Engine: Engine{Type: mbr.ww.Type(), ID: mbr.id},
+				Err:    mbr.err,
+			})
+		}
+		mbr.m.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes all engine watchers as well as the event channels of this
+// Composite.
+func (c *Composite) Close() {
+	for _, mbr := range c.members {
+		mbr.ww.Close()
+	}
+	c.eventchmux.Lock()
+	defer c.eventchmux.Unlock()
+	for _, evs := range c.eventchs {
+		close(evs)
+	}
+	c.eventchs = nil
+	c.closed = true
+}
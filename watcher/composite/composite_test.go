@@ -0,0 +1,163 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+)
+
+// fakeWatcher is an engine watcher with a portfolio, events, and readiness
+// fully controlled by tests.
+type fakeWatcher struct {
+	watcher.Watcher
+	typ   string
+	id    string
+	err   error // returned from Watch instead of waiting for cancellation.
+	pf    *whalewatcher.Portfolio
+	evs   chan watcher.ContainerEvent
+	ready chan struct{}
+	close func()
+}
+
+func newFakeWatcher(typ, id string) *fakeWatcher {
+	w := &fakeWatcher{
+		typ:   typ,
+		id:    id,
+		pf:    whalewatcher.NewPortfolio(),
+		evs:   make(chan watcher.ContainerEvent),
+		ready: make(chan struct{}),
+	}
+	w.close = sync.OnceFunc(func() { close(w.evs) })
+	return w
+}
+
+func (w *fakeWatcher) Type() string                          { return w.typ }
+func (w *fakeWatcher) ID(context.Context) string             { return w.id }
+func (w *fakeWatcher) Portfolio() *whalewatcher.Portfolio    { return w.pf }
+func (w *fakeWatcher) Events() <-chan watcher.ContainerEvent { return w.evs }
+func (w *fakeWatcher) Ready() <-chan struct{}                { return w.ready }
+func (w *fakeWatcher) Close()                                { w.close() }
+
+func (w *fakeWatcher) Watch(ctx context.Context) error {
+	if w.err != nil {
+		return w.err
+	}
+	close(w.ready)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// born adds the container to the portfolio and then notifies about it.
+func (w *fakeWatcher) born(cntr *whalewatcher.Container) {
+	w.pf.Add(cntr)
+	w.evs <- watcher.ContainerEvent{Type: engineclient.ContainerStarted, Container: cntr}
+}
+
+var _ = Describe("composite watcher", func() {
+
+	BeforeEach(func() {
+		goodgos := Goroutines()
+		DeferCleanup(func() {
+			Eventually(Goroutines).Within(2 * time.Second).ProbeEvery(100 * time.Millisecond).
+				ShouldNot(HaveLeaked(goodgos))
+		})
+	})
+
+	It("merges portfolios and events", func(ctx context.Context) {
+		docker := newFakeWatcher("docker.com", "D0CKER")
+		containerd := newFakeWatcher("containerd.io", "C0NTAINERD")
+		c := New(docker, containerd)
+		defer c.Close()
+		Expect(c.Watchers()).To(HaveExactElements(docker, containerd))
+		evs := c.Events()
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() { done <- c.Watch(ctx) }()
+		Eventually(c.Ready()).Should(BeClosed())
+		dockerEngine := Engine{Type: "docker.com", ID: "D0CKER"}
+		containerdEngine := Engine{Type: "containerd.io", ID: "C0NTAINERD"}
+		Expect(c.Engines()).To(HaveExactElements(dockerEngine, containerdEngine))
+		Expect(dockerEngine.String()).To(Equal("docker.com/D0CKER"))
+
+		docker.born(&whalewatcher.Container{ID: "1", Name: "furious_furuncle", PID: 42})
+		Eventually(evs).Should(Receive(And(
+			HaveField("Engine", dockerEngine),
+			HaveField("Type", engineclient.ContainerStarted),
+			HaveField("Container.Name", "furious_furuncle"))))
+		containerd.born(&whalewatcher.Container{ID: "1", Name: "mad_moby", PID: 666})
+		Eventually(evs).Should(Receive(HaveField("Engine", containerdEngine)))
+
+		pf := c.Portfolio()
+		Expect(pf.Engines()).To(HaveExactElements(dockerEngine, containerdEngine))
+		Expect(pf.ContainerTotal()).To(Equal(2))
+		Expect(pf.Containers("1")).To(HaveExactElements(
+			And(HaveField("Engine", dockerEngine), HaveField("Name", "furious_furuncle")),
+			And(HaveField("Engine", containerdEngine), HaveField("Name", "mad_moby"))))
+		Expect(pf.Container(containerdEngine, "1")).To(HaveField("Name", "mad_moby"))
+		Expect(pf.Container(Engine{Type: "foo"}, "1")).To(BeNil())
+		Expect(pf.Snapshot(dockerEngine).Container("furious_furuncle")).NotTo(BeNil())
+		var names []string
+		for cntr := range pf.AllContainers() {
+			names = append(names, cntr.Engine.Type+":"+cntr.Name)
+		}
+		Expect(names).To(HaveExactElements("docker.com:furious_furuncle", "containerd.io:mad_moby"))
+
+		Expect(c.Status()).To(HaveEach(And(HaveField("Ready", true), HaveField("Err", BeNil()))))
+		cancel()
+		Eventually(done).Should(Receive(MatchError(context.Canceled)))
+		Expect(c.Status()).To(HaveEach(HaveField("Err", MatchError(context.Canceled))))
+
+		c.Close()
+		Eventually(evs).Should(BeClosed())
+		Expect(c.Events()).To(BeClosed())
+	})
+
+	It("reports per-engine errors", func(ctx context.Context) {
+		docker := newFakeWatcher("docker.com", "D0CKER")
+		broken := newFakeWatcher("containerd.io", "")
+		broken.err = errors.New("no containerd here")
+		c := New(docker, broken)
+		defer c.Close()
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() { done <- c.Watch(ctx) }()
+		Eventually(func() []Status { return c.Status() }).Should(HaveExactElements(
+			HaveField("Ready", true),
+			And(HaveField("Ready", false), HaveField("Err", MatchError("no containerd here")))))
+		Consistently(c.Ready()).ShouldNot(BeClosed())
+
+		cancel()
+		var err error
+		Eventually(done).Should(Receive(&err))
+		var engerr *EngineError
+		Expect(errors.As(err, &engerr)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("engine containerd.io/: no containerd here")))
+		Expect(err).To(MatchError(context.Canceled))
+	})
+
+})
@@ -0,0 +1,172 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"slices"
+
+	"github.com/thediveo/whalewatcher/v2"
+)
+
+// DefaultOwnership ranks engine types from the highest-level to the
+// lowest-level engine: when multiple engines see the same workload, the
+// highest-ranking engine owns it. CRI (type "k8s.io/cri-api") thus owns the
+// Kubernetes containers also visible in containerd's "k8s.io" namespace, and
+// Docker (type "docker.com") owns the containers also visible in containerd's
+// "moby" namespace. Podman (type "podman.io") owns the containers also visible
+// in the OCI runtime state directories of runc and crun, so OCI runtimes (type
+// "opencontainers.org/runtime") rank last. Engine types not listed rank lowest.
+var DefaultOwnership = []string{
+	"k8s.io/cri-api",
+	"docker.com",
+	"podman.io",
+	"containerd.io",
+	"opencontainers.org/runtime",
+}
+
+// Workload is a single workload as seen by one or more container engines.
+type Workload struct {
+	Owner      Container   // the container as seen by the owning engine.
+	Containers []Container // the container as seen by all engines, owner first.
+}
+
+// Correlation correlates the containers of multiple engines representing the
+// same workloads.
+type Correlation struct {
+	workloads []*Workload
+	of        map[Container]*Workload
+}
+
+// nsKey identifies a container by its mount and PID namespaces; a container
+// has its own mount namespace, but it might share its PID namespace with other
+// containers, such as in Kubernetes pods, and vice versa.
+type nsKey struct {
+	mnt uint64
+	pid uint64
+}
+
+// Correlate the containers of this portfolio, recognizing the same workload
+// across engines by the PID of its initial process or, if known, by its mount
+// and PID namespaces. Among the engines seeing the same workload, the engine
+// whose type ranks highest in the specified ownership owns the workload; ties
+// are broken by the order of the engines in the portfolio. If ownership is
+// nil, then [DefaultOwnership] applies.
+func (p *Portfolio) Correlate(ownership []string) *Correlation {
+	if ownership == nil {
+		ownership = DefaultOwnership
+	}
+	cntrs := slices.Collect(p.AllContainers())
+	// Group the containers using a union-find with path halving, joining
+	// containers with the same PID or the same mount and PID namespaces.
+	parents := make([]int, len(cntrs))
+	for idx := range parents {
+		parents[idx] = idx
+	}
+	find := func(idx int) int {
+		for parents[idx] != idx {
+			parents[idx] = parents[parents[idx]]
+			idx = parents[idx]
+		}
+		return idx
+	}
+	union := func(a, b int) {
+		if ra, rb := find(a), find(b); ra != rb {
+			parents[max(ra, rb)] = min(ra, rb)
+		}
+	}
+	pids := map[int]int{}
+	nss := map[nsKey]int{}
+	for idx, cntr := range cntrs {
+		if cntr.PID > 0 {
+			if other, ok := pids[cntr.PID]; ok {
+				union(idx, other)
+			} else {
+				pids[cntr.PID] = idx
+			}
+		}
+		key := nsKey{
+			mnt: cntr.Namespaces[whalewatcher.MountNamespace],
+			pid: cntr.Namespaces[whalewatcher.PIDNamespace],
+		}
+		if key.mnt != 0 && key.pid != 0 {
+			if other, ok := nss[key]; ok {
+				union(idx, other)
+			} else {
+				nss[key] = idx
+			}
+		}
+	}
+	// Now gather the workloads, with the containers of each workload sorted
+	// by ownership rank; as we're iterating in the order of engines and the
+	// sorting is stable, ties are broken by engine order.
+	rank := func(cntr Container) int {
+		if idx := slices.Index(ownership, cntr.Engine.Type); idx >= 0 {
+			return idx
+		}
+		return len(ownership)
+	}
+	c := &Correlation{of: map[Container]*Workload{}}
+	roots := map[int]*Workload{}
+	for idx, cntr := range cntrs {
+		root := find(idx)
+		w, ok := roots[root]
+		if !ok {
+			w = &Workload{}
+			roots[root] = w
+			c.workloads = append(c.workloads, w)
+		}
+		w.Containers = append(w.Containers, cntr)
+		c.of[cntr] = w
+	}
+	for _, w := range c.workloads {
+		slices.SortStableFunc(w.Containers, func(a, b Container) int {
+			return rank(a) - rank(b)
+		})
+		w.Owner = w.Containers[0]
+	}
+	return c
+}
+
+// Workloads returns the correlated workloads, in the order of their first
+// containers in the portfolio.
+func (c *Correlation) Workloads() []*Workload {
+	return slices.Clone(c.workloads)
+}
+
+// WorkloadOf returns the workload the specified container belongs to, or nil
+// if the container isn't part of the correlated portfolio.
+func (c *Correlation) WorkloadOf(cntr Container) *Workload {
+	return c.of[cntr]
+}
+
+// Owner returns the container of the owning engine for the workload the
+// specified container belongs to. If the container isn't part of the
+// correlated portfolio, then the zero Container is returned instead.
+func (c *Correlation) Owner(cntr Container) Container {
+	if w := c.of[cntr]; w != nil {
+		return w.Owner
+	}
+	return Container{}
+}
+
+// IsOwner returns true if the specified container is the container of the
+// engine owning its workload. Iterating only over the owned containers thus
+// avoids counting the same workload multiple times.
+func (c *Correlation) IsOwner(cntr Container) bool {
+	if w := c.of[cntr]; w != nil {
+		return w.Owner == cntr
+	}
+	return false
+}
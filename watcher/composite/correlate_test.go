@@ -0,0 +1,127 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// portfolio returns a merged portfolio of the specified engines and their
+// containers.
+func portfolio(engines []Engine, cntrs ...[]*whalewatcher.Container) *Portfolio {
+	p := &Portfolio{engines: engines}
+	for _, engineCntrs := range cntrs {
+		pf := whalewatcher.NewPortfolio()
+		for _, cntr := range engineCntrs {
+			pf.Add(cntr)
+		}
+		p.snapshots = append(p.snapshots, pf.Snapshot())
+	}
+	return p
+}
+
+var _ = Describe("correlating workloads", func() {
+
+	containerd := Engine{Type: "containerd.io", ID: "C0NTAINERD"}
+	docker := Engine{Type: "docker.com", ID: "D0CKER"}
+	cri := Engine{Type: "k8s.io/cri-api", ID: "CR1"}
+
+	It("correlates by PID and namespaces", func() {
+		// containerd sees everything, including Docker's and Kubernetes'
+		// containers.
+		ctrFuruncle := &whalewatcher.Container{ID: "1", Name: "1", Project: "moby", PID: 42}
+		ctrPod := &whalewatcher.Container{ID: "2", Name: "2", Project: "k8s.io", PID: 666,
+			Namespaces: whalewatcher.Namespaces{whalewatcher.MountNamespace: 1, whalewatcher.PIDNamespace: 2}}
+		ctrLonely := &whalewatcher.Container{ID: "3", Name: "lonely_lumpy", PID: 1234}
+		dockerFuruncle := &whalewatcher.Container{ID: "1", Name: "furious_furuncle", PID: 42}
+		// CRI reports a different PID, but the same namespaces.
+		criPod := &whalewatcher.Container{ID: "2", Name: "pod_ptarmigan", PID: 1,
+			Namespaces: whalewatcher.Namespaces{whalewatcher.MountNamespace: 1, whalewatcher.PIDNamespace: 2}}
+		criOther := &whalewatcher.Container{ID: "4", Name: "other_ostrich", PID: 2,
+			Namespaces: whalewatcher.Namespaces{whalewatcher.MountNamespace: 3, whalewatcher.PIDNamespace: 2}}
+
+		p := portfolio([]Engine{containerd, docker, cri},
+			[]*whalewatcher.Container{ctrFuruncle, ctrPod, ctrLonely},
+			[]*whalewatcher.Container{dockerFuruncle},
+			[]*whalewatcher.Container{criPod, criOther})
+		c := p.Correlate(nil)
+
+		Expect(c.Workloads()).To(HaveLen(4))
+		var owned []string
+		for cntr := range p.AllContainers() {
+			if c.IsOwner(cntr) {
+				owned = append(owned, cntr.Name)
+			}
+		}
+		Expect(owned).To(ConsistOf("lonely_lumpy", "furious_furuncle", "pod_ptarmigan", "other_ostrich"))
+
+		w := c.WorkloadOf(Container{Engine: containerd, Container: ctrFuruncle})
+		Expect(w).NotTo(BeNil())
+		Expect(w.Owner).To(Equal(Container{Engine: docker, Container: dockerFuruncle}))
+		Expect(w.Containers).To(HaveExactElements(
+			Container{Engine: docker, Container: dockerFuruncle},
+			Container{Engine: containerd, Container: ctrFuruncle}))
+		Expect(c.Owner(Container{Engine: containerd, Container: ctrPod})).To(
+			Equal(Container{Engine: cri, Container: criPod}))
+		Expect(c.IsOwner(Container{Engine: containerd, Container: ctrPod})).To(BeFalse())
+	})
+
+	It("applies custom ownership and breaks ties by engine order", func() {
+		a := &whalewatcher.Container{ID: "1", Name: "a", PID: 42}
+		b := &whalewatcher.Container{ID: "1", Name: "b", PID: 42}
+		c := &whalewatcher.Container{ID: "1", Name: "c", PID: 42}
+		docker2 := Engine{Type: "docker.com", ID: "D0CKER2"}
+		p := portfolio([]Engine{docker, containerd, docker2},
+			[]*whalewatcher.Container{a}, []*whalewatcher.Container{b}, []*whalewatcher.Container{c})
+
+		Expect(p.Correlate(nil).Workloads()).To(ConsistOf(
+			HaveField("Owner.Name", "a")))
+		Expect(p.Correlate([]string{"containerd.io"}).Workloads()).To(ConsistOf(
+			HaveField("Containers", HaveExactElements(
+				HaveField("Name", "b"), HaveField("Name", "a"), HaveField("Name", "c")))))
+	})
+
+	It("lets Podman own the containers also seen by OCI runtimes", func() {
+		podman := Engine{Type: "podman.io", ID: "P0DMAN"}
+		ociruntime := Engine{Type: "opencontainers.org/runtime", ID: "/run/crun"}
+		ociPod := &whalewatcher.Container{ID: "1", Name: "1", PID: 42}
+		podmanPod := &whalewatcher.Container{ID: "1", Name: "podgy_podder", PID: 42}
+
+		for _, engines := range [][]Engine{{ociruntime, podman}, {podman, ociruntime}} {
+			cntrs := map[Engine][]*whalewatcher.Container{
+				podman:     {podmanPod},
+				ociruntime: {ociPod},
+			}
+			p := portfolio(engines, cntrs[engines[0]], cntrs[engines[1]])
+			Expect(p.Correlate(nil).Workloads()).To(ConsistOf(And(
+				HaveField("Owner", Container{Engine: podman, Container: podmanPod}),
+				HaveField("Containers", HaveExactElements(
+					Container{Engine: podman, Container: podmanPod},
+					Container{Engine: ociruntime, Container: ociPod})))))
+		}
+	})
+
+	It("handles unknown containers", func() {
+		c := portfolio(nil).Correlate(nil)
+		unknown := Container{Engine: docker, Container: &whalewatcher.Container{}}
+		Expect(c.WorkloadOf(unknown)).To(BeNil())
+		Expect(c.Owner(unknown)).To(BeZero())
+		Expect(c.IsOwner(unknown)).To(BeFalse())
+	})
+
+})
@@ -0,0 +1,51 @@
+/*
+Package composite combines multiple [watcher.Watcher] instances for different
+container engines, such as Docker, a standalone containerd, and a CRI runtime
+on the same node, into a single [Composite].
+
+# Usage
+
+	dockerw, _ := moby.New("", backoff.NewExponentialBackOff())
+	containerdw, _ := containerd.New("", backoff.NewExponentialBackOff())
+	c := composite.New(dockerw, containerdw)
+	defer c.Close()
+	evs := c.Events()
+	go c.Watch(ctx)
+	for ev := range evs {
+		fmt.Printf("%s: %d %s\n", ev.Engine, ev.Type, ev.Container.Name)
+	}
+
+A Composite offers:
+
+  - a merged [Portfolio] consisting of consistent snapshots of the individual
+    watchers' portfolios, with containers qualified by their [Engine]s;
+  - a single merged stream of container lifecycle [Event]s, attributed to the
+    engines they originate from;
+  - per-engine readiness and errors using [Composite.Status], as well as the
+    combined readiness using [Composite.Ready].
+
+Engines are identified by their types and IDs. As querying an engine's ID
+requires a round trip to the engine, a Composite queries the engine IDs when
+starting to watch and then again after the individual watchers have become
+ready, in case their engines were unreachable at first.
+
+# Cross-Engine Workloads
+
+The same workload might be visible to multiple engines: for instance, Docker
+containers also appear in containerd's "moby" namespace, and Kubernetes
+containers appear both via CRI and in containerd's "k8s.io" namespace. While
+the containerd engine client ignores these namespaces by default, they become
+visible when watching containerd with different ignored namespaces.
+
+[Portfolio.Correlate] recognizes the same workload across engines by the PIDs
+of the initial container processes or, if known, by their mount and PID
+namespaces; see also watcher.WithNamespaces. The resulting [Correlation]
+reports which engine owns a workload according to an engine ranking, such as
+[DefaultOwnership], so multi-engine setups can count each workload only once:
+
+	corr := c.Portfolio().Correlate(nil)
+	for _, workload := range corr.Workloads() {
+		fmt.Printf("%s owned by %s\n", workload.Owner.Name, workload.Owner.Engine)
+	}
+*/
+package composite
@@ -0,0 +1,95 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package composite
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/thediveo/whalewatcher/v2"
+)
+
+// Container is a container qualified by the engine it is managed by.
+type Container struct {
+	Engine Engine
+	*whalewatcher.Container
+}
+
+// Portfolio is a merged portfolio of multiple container engines, consisting of
+// consistent snapshots of the individual engine portfolios taken at
+// (roughly) the same time. As the snapshots are immutable, so is a merged
+// Portfolio.
+type Portfolio struct {
+	engines   []Engine
+	snapshots []*whalewatcher.Snapshot
+}
+
+// Engines returns the identifications of the engines in this portfolio.
+func (p *Portfolio) Engines() []Engine {
+	return slices.Clone(p.engines)
+}
+
+// Snapshot returns the portfolio snapshot of the specified engine, or nil if
+// the engine isn't part of this portfolio.
+func (p *Portfolio) Snapshot(engine Engine) *whalewatcher.Snapshot {
+	if idx := slices.Index(p.engines, engine); idx >= 0 {
+		return p.snapshots[idx]
+	}
+	return nil
+}
+
+// Container returns the container with the specified ID or name managed by the
+// specified engine, or nil if there is no such container.
+func (p *Portfolio) Container(engine Engine, nameorid string) *whalewatcher.Container {
+	if s := p.Snapshot(engine); s != nil {
+		return s.Container(nameorid)
+	}
+	return nil
+}
+
+// Containers returns the containers with the specified ID or name from all
+// engines, in the order of the engines. As container IDs and names are only
+// unique per engine, there might be multiple such containers.
+func (p *Portfolio) Containers(nameorid string) []Container {
+	var cntrs []Container
+	for idx, s := range p.snapshots {
+		if cntr := s.Container(nameorid); cntr != nil {
+			cntrs = append(cntrs, Container{Engine: p.engines[idx], Container: cntr})
+		}
+	}
+	return cntrs
+}
+
+// ContainerTotal returns the total number of containers over all engines.
+func (p *Portfolio) ContainerTotal() (total int) {
+	for _, s := range p.snapshots {
+		total += s.ContainerTotal()
+	}
+	return
+}
+
+// AllContainers returns an iterator over all containers of all engines, in the
+// order of the engines.
+func (p *Portfolio) AllContainers() iter.Seq[Container] {
+	return func(yield func(Container) bool) {
+		for idx, s := range p.snapshots {
+			for cntr := range s.AllContainers() {
+				if !yield(Container{Engine: p.engines[idx], Container: cntr}) {
+					return
+				}
+			}
+		}
+	}
+}
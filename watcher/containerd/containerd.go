@@ -38,7 +38,7 @@ const Type = engineclient.Type
 // Finally, containerd engine client-specific options can be passed in.
 func New(containerdsock string, buggeroff backoff.BackOff, opts ...engineclient.NewOption) (watcher.Watcher, error) {
 	if containerdsock == "" {
-		containerdsock = "/run/containerd/containerd.sock"
+		containerdsock = engineclient.DefaultSocket
 	}
 	cdclient, err := client.New(containerdsock)
 	if err != nil {
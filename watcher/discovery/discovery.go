@@ -0,0 +1,289 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/moby/moby/client"
+
+	"github.com/thediveo/whalewatcher/v2/watcher"
+	"github.com/thediveo/whalewatcher/v2/watcher/containerd"
+	"github.com/thediveo/whalewatcher/v2/watcher/cri"
+	"github.com/thediveo/whalewatcher/v2/watcher/moby"
+	"github.com/thediveo/whalewatcher/v2/watcher/podman"
+)
+
+// DefaultTimeout is the default time allowed for identifying the engine behind
+// a single probed socket.
+const DefaultTimeout = 2 * time.Second
+
+// PodmanComponent is the name of the server version component identifying a
+// Docker API actually served by Podman.
+const PodmanComponent = "Podman Engine"
+
+// Probe is a socket path to probe for a container engine of a particular type.
+type Probe struct {
+	Type string // engine type, such as "docker.com".
+	Path string // absolute path of the engine's API socket.
+}
+
+// DefaultProbes returns the well-known API sockets of rootful and rootless
+// container engines, with the rootless engine sockets located in the specified
+// runtime directory; see also [RuntimeDir]. Podman comes first, so that a
+// Docker socket symbolically linked to Podman's socket gets properly watched
+// by a Podman watcher.
+func DefaultProbes(runtimedir string) []Probe {
+	probes := []Probe{
+		{Type: podman.Type, Path: "/run/podman/podman.sock"},
+		{Type: moby.Type, Path: "/run/docker.sock"},
+		{Type: moby.Type, Path: "/var/run/docker.sock"},
+		{Type: containerd.Type, Path: "/run/containerd/containerd.sock"},
+		{Type: cri.Type, Path: "/run/crio/crio.sock"},
+	}
+	if runtimedir != "" {
+		probes = append(probes,
+			Probe{Type: podman.Type, Path: filepath.Join(runtimedir, "podman", "podman.sock")},
+			Probe{Type: moby.Type, Path: filepath.Join(runtimedir, "docker.sock")},
+		)
+	}
+	return probes
+}
+
+// RuntimeDir returns the current user's runtime directory, as specified by the
+// XDG_RUNTIME_DIR environment variable and otherwise defaulting to
+// "/run/user/UID". When running as root, RuntimeDir returns "" unless the
+// environment variable is set, as root doesn't run rootless engines.
+func RuntimeDir() string {
+	if rundir := os.Getenv("XDG_RUNTIME_DIR"); rundir != "" {
+		return rundir
+	}
+	if euid := os.Geteuid(); euid != 0 {
+		return fmt.Sprintf("/run/user/%d", euid)
+	}
+	return ""
+}
+
+// Constructor returns a new Watcher for the engine with the specified API
+// socket path, using the specified backoff.
+type Constructor func(sockpath string, buggeroff backoff.BackOff) (watcher.Watcher, error)
+
+// Option configures the discovery of container engines.
+type Option func(*discoverer)
+
+type discoverer struct {
+	root         string                 // root directory of the probed paths.
+	runtimedir   string                 // runtime directory for rootless engines.
+	probes       []Probe                // if nil, the default probes apply.
+	constructors map[string]Constructor // watcher constructors by engine type.
+	newBackoff   func() backoff.BackOff // optional backoff factory.
+	timeout      time.Duration          // time allowed per identification.
+}
+
+// WithRoot places all probed paths below the specified root directory,
+// including the paths in the runtime directory of rootless engines.
+func WithRoot(root string) Option {
+	return func(d *discoverer) {
+		d.root = root
+	}
+}
+
+// WithRuntimeDir sets the runtime directory containing the sockets of rootless
+// engines, overriding the default of [RuntimeDir].
+func WithRuntimeDir(dir string) Option {
+	return func(d *discoverer) {
+		d.runtimedir = dir
+	}
+}
+
+// WithProbes replaces the default probes with the specified probes.
+func WithProbes(probes ...Probe) Option {
+	return func(d *discoverer) {
+		d.probes = probes
+	}
+}
+
+// WithConstructor sets the watcher constructor for the specified engine type,
+// replacing any existing constructor for the same engine type. Probes for
+// engine types without any constructor are skipped.
+func WithConstructor(typ string, c Constructor) Option {
+	return func(d *discoverer) {
+		d.constructors[typ] = c
+	}
+}
+
+// WithBackoff sets the factory for the backoffs to use with the discovered
+// watchers; as backoffs are stateful, each watcher needs its own backoff. If
+// not set, the watchers will never retry.
+func WithBackoff(newbackoff func() backoff.BackOff) Option {
+	return func(d *discoverer) {
+		d.newBackoff = newbackoff
+	}
+}
+
+// WithTimeout sets the time allowed for identifying the engine behind a single
+// probed socket, defaulting to [DefaultTimeout].
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *discoverer) {
+		d.timeout = timeout
+	}
+}
+
+// Discover the container engines on this host by probing the well-known API
+// sockets, returning Watchers for the engines found. The caller takes
+// ownership of the returned watchers and is responsible for closing them.
+// Probing a socket that doesn't exist or without any engine listening isn't an
+// error; however, if the specified context gets cancelled, Discover closes any
+// watchers found so far and returns the context's error.
+func Discover(ctx context.Context, opts ...Option) ([]watcher.Watcher, error) {
+	d := &discoverer{
+		runtimedir: RuntimeDir(),
+		constructors: map[string]Constructor{
+			moby.Type: func(sockpath string, buggeroff backoff.BackOff) (watcher.Watcher, error) {
+				return moby.New("unix://"+sockpath, buggeroff)
+			},
+			containerd.Type: func(sockpath string, buggeroff backoff.BackOff) (watcher.Watcher, error) {
+				return containerd.New(sockpath, buggeroff)
+			},
+			cri.Type: func(sockpath string, buggeroff backoff.BackOff) (watcher.Watcher, error) {
+				return cri.New(sockpath, buggeroff)
+			},
+			podman.Type: func(sockpath string, buggeroff backoff.BackOff) (watcher.Watcher, error) {
+				return podman.New("unix://"+sockpath, buggeroff)
+			},
+		},
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	probes := d.probes
+	if probes == nil {
+		probes = DefaultProbes(d.runtimedir)
+	}
+
+	type engine struct{ typ, id string }
+	var watchers []watcher.Watcher
+	sockets := map[string]struct{}{}
+	engines := map[engine]struct{}{}
+	for _, probe := range probes {
+		if err := ctx.Err(); err != nil {
+			for _, w := range watchers {
+				w.Close()
+			}
+			return nil, err
+		}
+		sockpath, ok := socket(filepath.Join(d.root, probe.Path))
+		if !ok {
+			continue
+		}
+		if _, ok := sockets[sockpath]; ok {
+			continue
+		}
+		w, id := d.identify(ctx, probe.Type, sockpath)
+		if w == nil {
+			continue
+		}
+		sockets[sockpath] = struct{}{}
+		// The same engine might be reachable via different sockets that
+		// aren't symbolically linked to each other, so we need to check the
+		// engine IDs, too.
+		if _, ok := engines[engine{typ: w.Type(), id: id}]; ok {
+			w.Close()
+			continue
+		}
+		engines[engine{typ: w.Type(), id: id}] = struct{}{}
+		watchers = append(watchers, w)
+	}
+	return watchers, nil
+}
+
+// socket returns the specified path with all symbolic links resolved, if it
+// exists and is a socket.
+func socket(path string) (string, bool) {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false
+	}
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode().Type() != fs.ModeSocket {
+		return "", false
+	}
+	return path, true
+}
+
+// identify the engine of the specified type behind the specified socket,
+// returning a new watcher for it together with its engine ID. If there is no
+// (responsive) engine, identify returns a nil watcher instead.
+func (d *discoverer) identify(ctx context.Context, typ string, sockpath string) (watcher.Watcher, string) {
+	construct := d.constructors[typ]
+	if construct == nil {
+		return nil, ""
+	}
+	var buggeroff backoff.BackOff
+	if d.newBackoff != nil {
+		buggeroff = d.newBackoff()
+	}
+	w, err := construct(sockpath, buggeroff)
+	if err != nil {
+		return nil, ""
+	}
+	idctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+	if typ == moby.Type && servedByPodman(idctx, w.Client()) {
+		if pw, id := d.identify(ctx, podman.Type, sockpath); pw != nil {
+			w.Close()
+			return pw, id
+		}
+	}
+	id := w.ID(idctx)
+	if id == "" {
+		w.Close()
+		return nil, ""
+	}
+	return w, id
+}
+
+// serverVersioner is the subset of the Docker API client used to identify the
+// server behind the Docker API.
+type serverVersioner interface {
+	ServerVersion(ctx context.Context, options client.ServerVersionOptions) (client.ServerVersionResult, error)
+}
+
+// servedByPodman returns true if the specified Docker API client talks to a
+// Podman service instead of a Docker daemon.
+func servedByPodman(ctx context.Context, apiclient any) bool {
+	sv, ok := apiclient.(serverVersioner)
+	if !ok {
+		return false
+	}
+	version, err := sv.ServerVersion(ctx, client.ServerVersionOptions{})
+	if err != nil {
+		return false
+	}
+	for _, component := range version.Components {
+		if strings.EqualFold(component.Name, PodmanComponent) {
+			return true
+		}
+	}
+	return false
+}
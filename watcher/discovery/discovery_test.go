@@ -0,0 +1,211 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/client"
+
+	"github.com/thediveo/whalewatcher/v2/test/mockingpodman"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+	"github.com/thediveo/whalewatcher/v2/watcher/containerd"
+	"github.com/thediveo/whalewatcher/v2/watcher/moby"
+	"github.com/thediveo/whalewatcher/v2/watcher/podman"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+// symlink creates a symbolic link at the specified path below the root
+// directory, pointing to the specified socket of a mocked Podman service.
+func symlink(root, path string, mp *mockingpodman.MockingPodman) {
+	GinkgoHelper()
+	path = filepath.Join(root, path)
+	Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+	Expect(os.Symlink(strings.TrimPrefix(mp.Host(), "unix://"), path)).To(Succeed())
+}
+
+// staleSocket creates a unix socket at the specified path below the root
+// directory without anyone listening on it anymore.
+func staleSocket(root, path string) {
+	GinkgoHelper()
+	path = filepath.Join(root, path)
+	Expect(os.MkdirAll(filepath.Dir(path), 0o755)).To(Succeed())
+	l := Successful(net.Listen("unix", path))
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	Expect(l.Close()).To(Succeed())
+}
+
+type fakeVersioner struct {
+	components []system.ComponentVersion
+	err        error
+}
+
+func (v *fakeVersioner) ServerVersion(context.Context, client.ServerVersionOptions) (client.ServerVersionResult, error) {
+	return client.ServerVersionResult{Components: v.components}, v.err
+}
+
+var _ = Describe("engine discovery", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("returns the well-known sockets", func() {
+		Expect(DefaultProbes("")).To(ContainElements(
+			Probe{Type: podman.Type, Path: "/run/podman/podman.sock"},
+			Probe{Type: moby.Type, Path: "/run/docker.sock"},
+			Probe{Type: containerd.Type, Path: "/run/containerd/containerd.sock"},
+		))
+		Expect(DefaultProbes("")).NotTo(ContainElement(
+			HaveField("Path", HavePrefix("/run/user/"))))
+		Expect(DefaultProbes("/run/user/1000")).To(ContainElements(
+			Probe{Type: podman.Type, Path: "/run/user/1000/podman/podman.sock"},
+			Probe{Type: moby.Type, Path: "/run/user/1000/docker.sock"},
+		))
+	})
+
+	It("determines the runtime directory", func() {
+		GinkgoT().Setenv("XDG_RUNTIME_DIR", "/run/user/666")
+		Expect(RuntimeDir()).To(Equal("/run/user/666"))
+		GinkgoT().Setenv("XDG_RUNTIME_DIR", "")
+		if os.Geteuid() == 0 {
+			Expect(RuntimeDir()).To(BeEmpty())
+		} else {
+			Expect(RuntimeDir()).To(HavePrefix("/run/user/"))
+		}
+	})
+
+	It("detects Podman serving the Docker API", func(ctx context.Context) {
+		Expect(servedByPodman(ctx, nil)).To(BeFalse())
+		Expect(servedByPodman(ctx, &fakeVersioner{err: errors.New("D'OH!")})).To(BeFalse())
+		Expect(servedByPodman(ctx, &fakeVersioner{
+			components: []system.ComponentVersion{{Name: "Engine"}},
+		})).To(BeFalse())
+		Expect(servedByPodman(ctx, &fakeVersioner{
+			components: []system.ComponentVersion{{Name: "Conmon"}, {Name: "Podman Engine"}},
+		})).To(BeTrue())
+	})
+
+	It("discovers engines, skipping stale sockets and duplicates", func(ctx context.Context) {
+		mp := Successful(mockingpodman.New())
+		defer mp.Close()
+
+		root := GinkgoT().TempDir()
+		symlink(root, "/run/podman/podman.sock", mp)
+		symlink(root, "/run/docker.sock", mp)
+		staleSocket(root, "/var/run/docker.sock")
+		Expect(os.MkdirAll(filepath.Join(root, "/run/containerd"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(root, "/run/containerd/containerd.sock"), nil, 0o644)).To(Succeed())
+		// the same engine via a different socket.
+		symlink(root, "/run/user/1000/podman/podman.sock", mp)
+
+		watchers := Successful(Discover(ctx,
+			WithRoot(root),
+			WithRuntimeDir("/run/user/1000"),
+			WithTimeout(time.Second),
+			WithBackoff(func() backoff.BackOff { return &backoff.StopBackOff{} })))
+		defer func() {
+			for _, w := range watchers {
+				w.Close()
+			}
+		}()
+		Expect(watchers).To(HaveExactElements(
+			SatisfyAll(
+				HaveField("Type()", podman.Type),
+				HaveField("API()", mp.Host()),
+			)))
+	})
+
+	It("discovers rootless engines", func(ctx context.Context) {
+		mp := Successful(mockingpodman.New())
+		defer mp.Close()
+
+		root := GinkgoT().TempDir()
+		symlink(root, "/run/user/1000/podman/podman.sock", mp)
+
+		watchers := Successful(Discover(ctx,
+			WithRoot(root),
+			WithRuntimeDir("/run/user/1000")))
+		defer func() {
+			for _, w := range watchers {
+				w.Close()
+			}
+		}()
+		Expect(watchers).To(HaveExactElements(HaveField("Type()", podman.Type)))
+		Expect(watchers[0].ID(ctx)).NotTo(BeEmpty())
+	})
+
+	It("skips engine types without or with failing constructors", func(ctx context.Context) {
+		mp := Successful(mockingpodman.New())
+		defer mp.Close()
+
+		root := GinkgoT().TempDir()
+		symlink(root, "/foo.sock", mp)
+		symlink(root, "/bar.sock", mp)
+		probes := []Probe{
+			{Type: "foo.io", Path: "/foo.sock"},
+			{Type: "bar.io", Path: "/bar.sock"},
+		}
+		Expect(Discover(ctx,
+			WithRoot(root),
+			WithProbes(probes...),
+			WithConstructor("bar.io", func(string, backoff.BackOff) (watcher.Watcher, error) {
+				return nil, errors.New("D'OH!")
+			}))).To(BeEmpty())
+
+		watchers := Successful(Discover(ctx,
+			WithRoot(root),
+			WithProbes(probes...),
+			WithConstructor("bar.io", func(sockpath string, buggeroff backoff.BackOff) (watcher.Watcher, error) {
+				return podman.New(sockpath, buggeroff)
+			})))
+		defer func() {
+			for _, w := range watchers {
+				w.Close()
+			}
+		}()
+		Expect(watchers).To(HaveExactElements(HaveField("Type()", podman.Type)))
+	})
+
+	It("gives up when cancelled", func(ctx context.Context) {
+		mp := Successful(mockingpodman.New())
+		defer mp.Close()
+
+		root := GinkgoT().TempDir()
+		symlink(root, "/run/podman/podman.sock", mp)
+
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		Expect(Discover(ctx, WithRoot(root), WithRuntimeDir(""))).Error().To(MatchError(context.Canceled))
+	})
+
+})
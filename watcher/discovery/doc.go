@@ -0,0 +1,32 @@
+/*
+Package discovery automatically discovers the container engines on the local
+host by probing their well-known API sockets, returning ready-to-use
+[watcher.Watcher] instances for the engines found.
+
+# Usage
+
+	watchers, err := discovery.Discover(ctx,
+		discovery.WithBackoff(func() backoff.BackOff {
+			return backoff.NewExponentialBackOff()
+		}))
+	if err != nil {
+		panic(err)
+	}
+	c := composite.New(watchers...)
+	defer c.Close()
+
+Discover probes the sockets of rootful Podman, Docker, containerd and cri-o,
+as well as the sockets of rootless Podman and Docker in the current user's
+runtime directory. Probing follows symbolic links, so that the same socket
+isn't probed multiple times under different names. Then, the engine behind
+each socket is identified by querying its engine ID, skipping stale sockets
+without any engine listening. As Podman also serves the Docker API, a socket
+probed for Docker but served by Podman gets a Podman watcher instead. Finally,
+the same engine reachable via different sockets is watched only once.
+
+For testing, the probed paths can be placed below a different root directory
+using [WithRoot] and [WithRuntimeDir], or replaced altogether using
+[WithProbes]; the watcher construction for a particular engine type can be
+replaced using [WithConstructor].
+*/
+package discovery
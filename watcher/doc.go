@@ -24,6 +24,25 @@ namespaces. It's up to the API user to supply the correct PIDs where necessary
 and known. The watchers themselves do not need the PID information for their own
 operations.
 
+In order to pass options to the engine-agnostic watcher itself, create the
+watcher using [New] with an engine client, such as:
+
+	ww := watcher.New(moby.NewMobyWatcher(client), nil, watcher.WithNamespaces())
+
+[WithNamespaces] then discovers the Linux namespaces of the containers and
+[WithCgroups] their cgroups, while [WithProcfs] and [WithCgroupfs] specify
+different proc and cgroup filesystem locations. [WithGrouping] groups the containers
+in the watcher's portfolio differently than by composer project.
+[WithCheckpoint] warm starts a watcher from the last known portfolio saved to a
+checkpoint file, so that the portfolio isn't empty until the watcher has
+synchronized with its container engine.
+
+Alternatively, [Open] creates watchers from engine URLs, configuring the
+engine clients using the URLs' query parameters, such as:
+
+	import _ "github.com/thediveo/whalewatcher/v2/engineclient/all"
+	ww, err := watcher.Open("containerd:///run/containerd/containerd.sock?ignore=moby,k8s.io", nil)
+
 # Gory Details Notes
 
 The really difficult part here is to properly synchronize at the beginning with
@@ -0,0 +1,17 @@
+/*
+Package incus provides a container Watcher for Incus (and LXD) system
+containers.
+
+# Usage
+
+	import "github.com/thediveo/whalewatcher/v2/watcher/incus"
+	watcher, err := incus.New("", nil)
+
+When the API endpoint is left empty, the watcher connects to the default Incus
+API socket, unless overridden by the INCUS_DIR environment variable. In order
+to watch LXD instead, pass the LXD API socket and specify the LXD engine type:
+
+	watcher, err := incus.New(engineclient.LXDSocket, nil,
+	    engineclient.WithEngineType(engineclient.LXDType))
+*/
+package incus
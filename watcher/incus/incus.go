@@ -0,0 +1,44 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"github.com/cenkalti/backoff/v4"
+
+	engineclient "github.com/thediveo/whalewatcher/v2/engineclient/incus"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+)
+
+// Type ID of the container engine handled by this watcher.
+const Type = engineclient.Type
+
+// New returns a Watcher for keeping track of the currently alive system
+// containers, with the Incus projects they belong to as their composer
+// projects.
+//
+// When the incussock parameter is left empty then the default Incus API
+// socket applies, unless overridden by the INCUS_DIR environment variable.
+//
+// If the backoff is nil then the backoff defaults to backoff.StopBackOff, that
+// is, any failed operation will never be retried.
+//
+// Finally, Incus engine client-specific options can be passed in.
+func New(incussock string, buggeroff backoff.BackOff, opts ...engineclient.NewOption) (watcher.Watcher, error) {
+	client, err := engineclient.NewClient(incussock)
+	if err != nil {
+		return nil, err
+	}
+	return watcher.New(engineclient.NewIncusWatcher(client, opts...), buggeroff), nil
+}
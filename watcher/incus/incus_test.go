@@ -0,0 +1,86 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incus
+
+import (
+	"context"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient/incus"
+	"github.com/thediveo/whalewatcher/v2/test/mockingincus"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Incus engine watcher", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("doesn't accept invalid engine API paths", func() {
+		Expect(New("localhost:66666", nil)).Error().To(HaveOccurred())
+	})
+
+	It("watches system containers", func(ctx context.Context) {
+		mi := Successful(mockingincus.New())
+		defer mi.Close()
+		mi.AddInstance(mockingincus.MockedInstance{Name: "early-bird", Project: "birds", PID: 1111})
+
+		iw := Successful(New(mi.Socket(), nil, incus.WithPID(123456)))
+		defer iw.Close()
+		Expect(iw.Type()).To(Equal(Type))
+		Expect(iw.PID()).To(Equal(123456))
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = iw.Watch(ctx)
+		}()
+		Eventually(iw.Ready()).Should(BeClosed())
+
+		portfolio := func() []string {
+			if proj := iw.Portfolio().Project("birds"); proj != nil {
+				return proj.ContainerNames()
+			}
+			return []string{}
+		}
+		Eventually(portfolio).Should(ConsistOf("early-bird"))
+
+		mi.AddInstance(mockingincus.MockedInstance{Name: "late-bird", Project: "birds", PID: 2222})
+		Eventually(portfolio).Should(ConsistOf("early-bird", "late-bird"))
+
+		mi.PauseInstance("birds", "late-bird")
+		Eventually(func() bool {
+			return iw.Portfolio().Project("birds").Container("late-bird").Paused
+		}).Should(BeTrue())
+
+		mi.StopInstance("birds", "early-bird")
+		Eventually(portfolio).Should(ConsistOf("late-bird"))
+
+		cancel()
+		Eventually(done).WithTimeout(5 * time.Second).Should(BeClosed())
+	})
+
+})
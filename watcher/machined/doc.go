@@ -0,0 +1,12 @@
+/*
+Package machined provides a container Watcher for the containers registered
+with systemd-machined, such as systemd-nspawn containers.
+
+# Usage
+
+	import "github.com/thediveo/whalewatcher/v2/watcher/machined"
+	watcher, err := machined.New("", nil)
+
+When the D-Bus address is left empty, the watcher connects to the system bus.
+*/
+package machined
@@ -0,0 +1,44 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machined
+
+import (
+	"github.com/cenkalti/backoff/v4"
+
+	engineclient "github.com/thediveo/whalewatcher/v2/engineclient/machined"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+)
+
+// Type ID of the container engine handled by this watcher.
+const Type = engineclient.Type
+
+// New returns a Watcher for keeping track of the currently alive containers
+// registered with systemd-machined.
+//
+// When the address parameter is left empty then the watcher connects to the
+// system bus, otherwise to the D-Bus message bus with the specified address,
+// such as "unix:path=/run/dbus/system_bus_socket".
+//
+// If the backoff is nil then the backoff defaults to backoff.StopBackOff, that
+// is, any failed operation will never be retried.
+//
+// Finally, systemd-machined engine client-specific options can be passed in.
+func New(address string, buggeroff backoff.BackOff, opts ...engineclient.NewOption) (watcher.Watcher, error) {
+	client, err := engineclient.NewClient(address)
+	if err != nil {
+		return nil, err
+	}
+	return watcher.New(engineclient.NewMachinedWatcher(client, opts...), buggeroff), nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package machined
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient/machined"
+	"github.com/thediveo/whalewatcher/v2/test/mockingmachined"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("systemd-machined engine watcher", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("doesn't accept invalid D-Bus addresses", func() {
+		Expect(New("localhost:66666", nil)).Error().To(HaveOccurred())
+	})
+
+	It("watches nspawn containers", func(ctx context.Context) {
+		mm, err := mockingmachined.New()
+		if errors.Is(err, mockingmachined.ErrNoDBusDaemon) {
+			Skip("needs dbus-daemon")
+		}
+		Expect(err).NotTo(HaveOccurred())
+		defer mm.Close()
+		mm.AddMachine(mockingmachined.MockedMachine{Name: "early-bird", Leader: 1111})
+
+		mw := Successful(New(mm.Address(), nil, machined.WithPID(123456)))
+		defer mw.Close()
+		Expect(mw.Type()).To(Equal(Type))
+		Expect(mw.PID()).To(Equal(123456))
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = mw.Watch(ctx)
+		}()
+		Eventually(mw.Ready()).Should(BeClosed())
+
+		names := func() []string {
+			return mw.Portfolio().Project("").ContainerNames()
+		}
+		Eventually(names).Should(ConsistOf("early-bird"))
+
+		// The match rule for the machined signals might not yet be in place, so
+		// we keep nagging until we see the late bird.
+		Eventually(func() []string {
+			mm.RemoveMachine("late-bird")
+			mm.AddMachine(mockingmachined.MockedMachine{Name: "late-bird", Leader: 2222})
+			return names()
+		}).Should(ConsistOf("early-bird", "late-bird"))
+
+		mm.RemoveMachine("early-bird")
+		Eventually(names).Should(ConsistOf("late-bird"))
+
+		cancel()
+		Eventually(done).WithTimeout(5 * time.Second).Should(BeClosed())
+	})
+
+})
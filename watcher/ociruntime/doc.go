@@ -0,0 +1,17 @@
+/*
+Package ociruntime provides an "engineless" container Watcher for containers
+started directly using OCI runtimes, such as runc and crun, based on the OCI
+runtime state directories.
+
+# Usage
+
+	import (
+	    "github.com/thediveo/whalewatcher/v2/watcher/ociruntime"
+	    engineclient "github.com/thediveo/whalewatcher/v2/engineclient/ociruntime"
+	)
+	watcher := ociruntime.New(nil, engineclient.WithRoots("/run/runc"))
+
+Without any roots specified, the watcher watches the default runc and crun
+state roots of containers run as root.
+*/
+package ociruntime
@@ -0,0 +1,37 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"github.com/cenkalti/backoff/v4"
+
+	engineclient "github.com/thediveo/whalewatcher/v2/engineclient/ociruntime"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+)
+
+// Type ID of the container engine handled by this watcher.
+const Type = engineclient.Type
+
+// New returns a Watcher for keeping track of the currently alive containers
+// run directly by OCI runtimes, optionally with the composer projects they're
+// associated with. Use engineclient.WithRoots to specify the state root
+// directories to watch, otherwise they default to the runc and crun state
+// roots.
+//
+// If the backoff is nil then the backoff defaults to backoff.StopBackOff, that
+// is, any failed operation will never be retried.
+func New(buggeroff backoff.BackOff, opts ...engineclient.NewOption) watcher.Watcher {
+	return watcher.New(engineclient.NewOCIRuntimeWatcher(opts...), buggeroff)
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ociruntime
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient/ociruntime"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("OCI runtime watcher", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("watches engineless containers", func(ctx context.Context) {
+		root := GinkgoT().TempDir()
+		run := func(id string, status string) {
+			dir := filepath.Join(root, id)
+			Expect(os.MkdirAll(dir, 0o700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "state.json"), Successful(json.Marshal(map[string]any{
+				"id":          id,
+				"status":      status,
+				"pid":         os.Getpid(),
+				"annotations": map[string]string{ociruntime.ComposerProjectLabel: "birds"},
+			})), 0o600)).To(Succeed())
+		}
+		run("early-bird", ociruntime.StatusRunning)
+
+		ow := New(nil, ociruntime.WithRoots(root), ociruntime.WithPID(123456))
+		defer ow.Close()
+		Expect(ow.Type()).To(Equal(Type))
+		Expect(ow.PID()).To(Equal(123456))
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = ow.Watch(ctx)
+		}()
+		Eventually(ow.Ready()).Should(BeClosed())
+
+		portfolio := func() []string {
+			if proj := ow.Portfolio().Project("birds"); proj != nil {
+				return proj.ContainerNames()
+			}
+			return []string{}
+		}
+		Eventually(portfolio).Should(ConsistOf("early-bird"))
+
+		run("late-bird", ociruntime.StatusRunning)
+		Eventually(portfolio).Should(ConsistOf("early-bird", "late-bird"))
+
+		run("late-bird", ociruntime.StatusPaused)
+		Eventually(func() bool {
+			return ow.Portfolio().Project("birds").Container("late-bird").Paused
+		}).Should(BeTrue())
+
+		Expect(os.RemoveAll(filepath.Join(root, "early-bird"))).To(Succeed())
+		Eventually(portfolio).Should(ConsistOf("late-bird"))
+
+		cancel()
+		Eventually(done).WithTimeout(5 * time.Second).Should(BeClosed())
+	})
+
+})
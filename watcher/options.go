@@ -0,0 +1,99 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"github.com/thediveo/whalewatcher/v2"
+)
+
+// Option represents options to New when creating new watchers.
+type Option func(*watcher)
+
+// WithProcfs sets the root of the proc filesystem to use when discovering
+// additional container details, such as when the host's proc filesystem has
+// been mounted at a different location inside a container. It defaults to
+// "/proc".
+func WithProcfs(root string) Option {
+	return func(ww *watcher) {
+		ww.procfs = root
+	}
+}
+
+// WithNamespaces enables discovering the Linux namespaces of containers, as
+// long as the container engine client didn't already supply them. Namespace
+// discovery requires the initial container process PIDs to be valid in the
+// PID namespace of the proc filesystem used.
+func WithNamespaces() Option {
+	return func(ww *watcher) {
+		ww.namespaces = true
+	}
+}
+
+// WithCgroupfs sets the root of the cgroup filesystem to use when discovering
+// the cgroups of containers. It defaults to "/sys/fs/cgroup".
+func WithCgroupfs(root string) Option {
+	return func(ww *watcher) {
+		ww.cgroupfs = root
+	}
+}
+
+// WithCgroups enables discovering the cgroups of containers, as long as the
+// container engine client didn't already supply them. Similar to namespace
+// discovery, cgroup discovery requires the initial container process PIDs to
+// be valid in the PID namespace of the proc filesystem used.
+func WithCgroups() Option {
+	return func(ww *watcher) {
+		ww.cgroups = true
+	}
+}
+
+// WithGrouping sets the grouping of containers in the watcher's portfolio,
+// defaulting to [whalewatcher.GroupByProject].
+func WithGrouping(grouping whalewatcher.Grouping) Option {
+	return func(ww *watcher) {
+		ww.pfopts = append(ww.pfopts, whalewatcher.WithGrouping(grouping))
+	}
+}
+
+// WithCheckpoint enables warm starts from the portfolio checkpoint file at the
+// specified path: when creating a new watcher, the last known portfolio gets
+// loaded from the checkpoint file, if present, and then serves as the "still"
+// read portfolio until the watcher has synchronized with its container engine.
+// The watcher saves its portfolio to the checkpoint file after each
+// synchronization and when closing. The optional unpacker restores container
+// Rucksacks from the checkpoint; without an unpacker, Rucksacks of the loaded
+// containers stay in their JSON representation.
+//
+// Please note that no container lifecycle events are emitted for the loaded
+// containers; instead, events get emitted for the containers found alive when
+// synchronizing.
+func WithCheckpoint(path string, unpacker whalewatcher.RucksackUnpacker) Option {
+	return func(ww *watcher) {
+		ww.checkpoint = path
+		ww.unpacker = unpacker
+	}
+}
+
+// discover additional container details, as enabled by options. As containers
+// are considered to be immutable after they have been added to a portfolio,
+// discover must be called only before adding a container.
+func (ww *watcher) discover(cntr *whalewatcher.Container) {
+	if ww.namespaces && cntr.Namespaces == nil && cntr.PID > 0 {
+		cntr.Namespaces, _ = whalewatcher.ReadNamespaces(ww.procfs, cntr.PID)
+	}
+	if ww.cgroups && cntr.Cgroup == nil && cntr.PID > 0 {
+		cntr.Cgroup, _ = whalewatcher.ReadCgroup(ww.procfs, ww.cgroupfs, cntr.PID)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/thediveo/whalewatcher/v2"
+	"github.com/thediveo/whalewatcher/v2/engineclient/moby"
+	"github.com/thediveo/whalewatcher/v2/test/mockingmoby"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("watcher options", func() {
+
+	It("discovers namespaces", func() {
+		procfs := GinkgoT().TempDir()
+		nsdir := filepath.Join(procfs, "42", "ns")
+		Expect(os.MkdirAll(nsdir, 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(nsdir, "net"), nil, 0o644)).To(Succeed())
+		var stat unix.Stat_t
+		Expect(unix.Stat(filepath.Join(nsdir, "net"), &stat)).To(Succeed())
+
+		mm := mockingmoby.NewMockingMoby()
+		mm.AddContainer(mockingMoby)
+		mm.AddContainer(furiousFuruncle)
+		ww := New(moby.NewMobyWatcher(mm), nil, WithProcfs(procfs), WithNamespaces()).(*watcher)
+		DeferCleanup(ww.Close)
+
+		ww.born(context.Background(), mockingMoby.ID)
+		ww.born(context.Background(), furiousFuruncle.ID)
+		pf := ww.Portfolio()
+		Expect(pf.Container(mockingMoby.Name).Namespaces).To(Equal(
+			whalewatcher.Namespaces{whalewatcher.NetworkNamespace: stat.Ino}))
+		Expect(pf.Container(furiousFuruncle.Name).Namespaces).To(BeNil())
+		Expect(pf.ContainersInNamespace(whalewatcher.NetworkNamespace, stat.Ino)).To(ConsistOf(
+			HaveField("Name", mockingMoby.Name)))
+	})
+
+	It("discovers cgroups", func() {
+		procfs := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(procfs, "42"), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(procfs, "42", "cgroup"),
+			[]byte("0::/system.slice/docker-1234567890.scope\n"), 0o644)).To(Succeed())
+
+		mm := mockingmoby.NewMockingMoby()
+		mm.AddContainer(mockingMoby)
+		mm.AddContainer(furiousFuruncle)
+		ww := New(moby.NewMobyWatcher(mm), nil,
+			WithProcfs(procfs), WithCgroupfs("/cgroupfs"), WithCgroups()).(*watcher)
+		DeferCleanup(ww.Close)
+
+		Expect(ww.list(context.Background())).To(Succeed())
+		pf := ww.Portfolio()
+		Expect(pf.Container(mockingMoby.Name).Cgroup).To(Equal(&whalewatcher.Cgroup{
+			Path: "/system.slice/docker-1234567890.scope",
+			Dir:  "/cgroupfs/system.slice/docker-1234567890.scope",
+			V2:   true,
+		}))
+		Expect(pf.Container(furiousFuruncle.Name).Cgroup).To(BeNil())
+	})
+
+	It("groups containers", func() {
+		mm := mockingmoby.NewMockingMoby()
+		mm.AddContainer(mockingMoby)
+		ww := New(moby.NewMobyWatcher(mm), nil,
+			WithGrouping(whalewatcher.GroupByLabel("motto"))).(*watcher)
+		DeferCleanup(ww.Close)
+
+		ww.born(context.Background(), mockingMoby.ID)
+		pf := ww.Portfolio()
+		Expect(pf.Names()).To(ConsistOf("I'm not dead yet"))
+		ww.paused(mockingMoby.ID, "", false)
+		Expect(pf.Project("I'm not dead yet").Container(mockingMoby.ID)).To(HaveField("Paused", false))
+		ww.demised(mockingMoby.ID, "", nil)
+		Expect(pf.ContainerTotal()).To(BeZero())
+	})
+
+	It("warm starts from a checkpoint", func() {
+		checkpoint := filepath.Join(GinkgoT().TempDir(), "portfolio.json")
+		stale := whalewatcher.NewPortfolio()
+		stale.Add(&whalewatcher.Container{ID: "666", Name: "stale_sardine", PID: 666})
+		Expect(whalewatcher.SaveCheckpoint(checkpoint, stale)).To(Succeed())
+
+		mm := mockingmoby.NewMockingMoby()
+		mm.AddContainer(mockingMoby)
+		ww := New(moby.NewMobyWatcher(mm), nil, WithCheckpoint(checkpoint, nil)).(*watcher)
+		Expect(ww.Portfolio().Container("stale_sardine")).NotTo(BeNil())
+
+		Expect(ww.list(context.Background())).To(Succeed())
+		Expect(ww.Ready()).To(BeClosed())
+		Expect(ww.Portfolio().Container("stale_sardine")).To(BeNil())
+		Expect(ww.Portfolio().Container(mockingMoby.Name)).NotTo(BeNil())
+
+		ww.Close()
+		pf, err := whalewatcher.LoadCheckpoint(checkpoint, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pf.Container("stale_sardine")).To(BeNil())
+		Expect(pf.Container(mockingMoby.Name)).NotTo(BeNil())
+	})
+
+	It("doesn't checkpoint before synchronizing", func() {
+		checkpoint := filepath.Join(GinkgoT().TempDir(), "portfolio.json")
+		mm := mockingmoby.NewMockingMoby()
+		ww := New(moby.NewMobyWatcher(mm), nil, WithCheckpoint(checkpoint, nil)).(*watcher)
+		Expect(ww.Portfolio().ContainerTotal()).To(BeZero())
+		ww.Close()
+		Expect(checkpoint).NotTo(BeAnExistingFile())
+	})
+
+	It("doesn't discover details by default", func() {
+		mm := mockingmoby.NewMockingMoby()
+		mm.AddContainer(mockingMoby)
+		ww := New(moby.NewMobyWatcher(mm), nil).(*watcher)
+		DeferCleanup(ww.Close)
+
+		ww.born(context.Background(), mockingMoby.ID)
+		Expect(ww.Portfolio().Container(mockingMoby.Name).Namespaces).To(BeNil())
+		Expect(ww.Portfolio().Container(mockingMoby.Name).Cgroup).To(BeNil())
+	})
+
+})
@@ -0,0 +1,15 @@
+/*
+Package podman provides a container Watcher for Podman services, using
+Podman's native libpod API.
+
+# Usage
+
+	import "github.com/thediveo/whalewatcher/v2/watcher/podman"
+	watcher, err := podman.New("", nil)
+
+When the API endpoint is left empty, the watcher connects to either the rootful
+or the current user's rootless Podman service, depending on whether it is
+running as root. In order to watch a particular user's rootless Podman service,
+pass the endpoint in "unix:///run/user/UID/podman/podman.sock" format.
+*/
+package podman
@@ -0,0 +1,45 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"github.com/cenkalti/backoff/v4"
+
+	engineclient "github.com/thediveo/whalewatcher/v2/engineclient/podman"
+	"github.com/thediveo/whalewatcher/v2/watcher"
+)
+
+// Type ID of the container engine handled by this watcher.
+const Type = engineclient.Type
+
+// New returns a Watcher for keeping track of the currently alive containers,
+// with the Podman pods they belong to as their composer projects.
+//
+// When the podmansock parameter is left empty then the default Podman API
+// endpoint applies, that is, either the rootful or the current user's rootless
+// Podman API socket, unless overridden by the CONTAINER_HOST environment
+// variable.
+//
+// If the backoff is nil then the backoff defaults to backoff.StopBackOff, that
+// is, any failed operation will never be retried.
+//
+// Finally, Podman engine client-specific options can be passed in.
+func New(podmansock string, buggeroff backoff.BackOff, opts ...engineclient.NewOption) (watcher.Watcher, error) {
+	client, err := engineclient.NewClient(podmansock)
+	if err != nil {
+		return nil, err
+	}
+	return watcher.New(engineclient.NewPodmanWatcher(client, opts...), buggeroff), nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Harald Albrecht.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podman
+
+import (
+	"context"
+	"time"
+
+	"github.com/thediveo/whalewatcher/v2/engineclient/podman"
+	"github.com/thediveo/whalewatcher/v2/test/mockingpodman"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gleak"
+	. "github.com/thediveo/fdooze"
+	. "github.com/thediveo/success"
+)
+
+var _ = Describe("Podman engine watcher", func() {
+
+	BeforeEach(func() {
+		goodfds := Filedescriptors()
+		DeferCleanup(func() {
+			Eventually(Goroutines).ShouldNot(HaveLeaked())
+			Expect(Filedescriptors()).NotTo(HaveLeakedFds(goodfds))
+		})
+	})
+
+	It("doesn't accept invalid engine API paths", func() {
+		Expect(New("localhost:66666", nil)).Error().To(HaveOccurred())
+	})
+
+	It("watches pods", func(ctx context.Context) {
+		mp := Successful(mockingpodman.New())
+		defer mp.Close()
+
+		pw := Successful(New(mp.Host(), nil, podman.WithPID(123456)))
+		defer pw.Close()
+		Expect(pw.Type()).To(Equal(Type))
+		Expect(pw.PID()).To(Equal(123456))
+
+		ctx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_ = pw.Watch(ctx)
+		}()
+		Eventually(pw.Ready()).Should(BeClosed())
+
+		mp.AddPod(mockingpodman.MockedPod{ID: "pod-1", Name: "podgy"})
+		mp.AddContainer(mockingpodman.MockedContainer{
+			ID: "1111", Name: "podgy-infra", PID: 1111, Pod: "pod-1", Infra: true,
+		})
+		portfolio := func() []string {
+			if proj := pw.Portfolio().Project("podgy"); proj != nil {
+				return proj.ContainerNames()
+			}
+			return []string{}
+		}
+		Eventually(portfolio).Should(ConsistOf("podgy-infra"))
+
+		mp.StopContainer("1111")
+		Eventually(portfolio).Should(BeEmpty())
+
+		cancel()
+		Eventually(done).WithTimeout(5 * time.Second).Should(BeClosed())
+	})
+
+})
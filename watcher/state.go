@@ -50,3 +50,39 @@ func (pps *pendingPauseStates) Remove(id string) {
 		}
 	}
 }
+
+// healthState keeps track of the most recent health status of a container
+// identified by its ID.
+type healthState struct {
+	ID     string // container ID
+	Health string // health status, such as "healthy".
+}
+
+// pendingHealthStates is a list (queue) of pending container health statuses,
+// keeping only the most recent per particular container ID. Similar to
+// pendingPauseStates, it must be used only from a single go routine.
+type pendingHealthStates []healthState
+
+// Add or update the health status of the container with the given ID.
+func (phs *pendingHealthStates) Add(id string, health string) {
+	for idx, hs := range *phs {
+		if hs.ID == id {
+			(*phs)[idx].Health = health
+			return
+		}
+	}
+	*phs = append(*phs, healthState{ID: id, Health: health})
+}
+
+// Remove the health status of the container with the given ID. If there is no
+// such ID, then silently ignore the removal attempt.
+func (phs *pendingHealthStates) Remove(id string) {
+	for idx, hs := range *phs {
+		if hs.ID == id {
+			last := len(*phs) - 1
+			(*phs)[idx] = (*phs)[last]
+			*phs = (*phs)[:last]
+			return
+		}
+	}
+}
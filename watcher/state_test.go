@@ -46,3 +46,19 @@ var _ = Describe("pause state queue", func() {
 	})
 
 })
+
+var _ = Describe("health state queue", func() {
+
+	It("never adds twice and removes", func() {
+		q := pendingHealthStates{}
+		q.Add("foo", "starting")
+		q.Add("bar", "healthy")
+		q.Add("foo", "unhealthy")
+		Expect(q).To(HaveLen(2))
+		Expect(q[0]).To(Equal(healthState{ID: "foo", Health: "unhealthy"}))
+		q.Remove("foo")
+		Expect(q).To(ConsistOf(healthState{ID: "bar", Health: "healthy"}))
+		Expect(func() { q.Remove("foo") }).NotTo(Panic())
+	})
+
+})
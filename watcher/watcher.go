@@ -34,7 +34,8 @@ type Watcher interface {
 	// portfolio until the watcher has caught up with the new state after an
 	// engine reconnect. For this reason callers must not keep the returned
 	// Portfolio reference for longer periods of time, but just for what they
-	// immediately need to query a Portfolio for.
+	// immediately need to query a Portfolio for. Use the portfolio's Snapshot
+	// method to get a consistent view across multiple queries.
 	Portfolio() *whalewatcher.Portfolio
 	// Ready returns a channel that gets closed after the initial
 	// synchronization has been achieved. Watcher clients do not need to wait
@@ -72,10 +73,14 @@ type Watcher interface {
 }
 
 // ContainerEvent informs about a particular container becoming alive or
-// terminated, or paused and unpaused.
+// terminated, paused and unpaused, or changing its health status, with the
+// container then carrying its new health status. Additionally, it informs about other
+// container lifecycle events, such as a container having been created or
+// removed, see [engineclient.ContainerEventType.IsInformational].
 type ContainerEvent struct {
 	Type      engineclient.ContainerEventType
 	Container *whalewatcher.Container
+	Exit      *engineclient.ExitInfo // optional exit information for ContainerExited events.
 }
 
 // watcher watches a Docker daemon for containers to become alive and later
@@ -91,33 +96,74 @@ type watcher struct {
 	readportfolio  *whalewatcher.Portfolio // portfolio as seen by object users.
 	writeportfolio *whalewatcher.Portfolio // portfolio we're updating.
 
-	eventgate      sync.Mutex         // not a RWMutex as it doesn't buy us anything here.
-	listinprogress bool               // listing containers in progress.
-	bluenorwegians []string           // container IDs we know to have died while list in progress.
-	pauses         pendingPauseStates // (un)pause state changes while list in progress.
+	eventgate      sync.Mutex          // not a RWMutex as it doesn't buy us anything here.
+	listinprogress bool                // listing containers in progress.
+	bluenorwegians []string            // container IDs we know to have died while list in progress.
+	pauses         pendingPauseStates  // (un)pause state changes while list in progress.
+	healths        pendingHealthStates // health status changes while list in progress.
 
 	ready      chan struct{} // ready channel signal
 	closeReady func()        // idempotent ready channel closing
 
 	eventchmux sync.Mutex
 	eventchs   []chan ContainerEvent
+
+	procfs     string // proc filesystem root for discovering container details.
+	namespaces bool   // discover container namespaces.
+	cgroupfs   string // cgroup filesystem root for discovering container cgroups.
+	cgroups    bool   // discover container cgroups.
+
+	pfopts []whalewatcher.PortfolioOption // options for creating new portfolios.
+
+	checkpoint string                        // optional portfolio checkpoint file path.
+	unpacker   whalewatcher.RucksackUnpacker // optional Rucksack unpacker for checkpoints.
+	live       bool                          // read portfolio has been synchronized; protected by pfmux.
+}
+
+// Open returns a new Watcher for the container engine specified by the engine
+// URL, such as "containerd:///run/containerd/containerd.sock?ignore=moby,k8s.io"
+// or "docker+unix:///run/docker.sock"; please see [engineclient.Open] for
+// details. The engine client packages register their URL schemes when getting
+// imported, so either import the required engine client packages or import
+// "github.com/thediveo/whalewatcher/v2/engineclient/all" for all built-in
+// engine types. If the backoff is nil then the backoff defaults to
+// backoff.StopBackOff. Finally, watcher options can be passed in.
+func Open(engineurl string, buggeroff backoff.BackOff, opts ...Option) (Watcher, error) {
+	engine, err := engineclient.Open(engineurl)
+	if err != nil {
+		return nil, err
+	}
+	return New(engine, buggeroff, opts...), nil
 }
 
 // New returns a new Watcher tracking alive containers as they come and go,
 // using the specified container EngineClient. If the backoff is nil then the
 // backoff defaults to backoff.StopBackOff, that is, any failed operation will
-// never be retried.
-func New(engine engineclient.EngineClient, buggeroff backoff.BackOff) Watcher {
-	pf := whalewatcher.NewPortfolio()
+// never be retried. Finally, watcher options can be passed in.
+func New(engine engineclient.EngineClient, buggeroff backoff.BackOff, opts ...Option) Watcher {
 	if buggeroff == nil {
 		buggeroff = &backoff.StopBackOff{}
 	}
 	ww := &watcher{
-		engine:         engine,
-		buggeroff:      buggeroff,
-		readportfolio:  pf,
-		writeportfolio: pf,
-		ready:          make(chan struct{}),
+		engine:    engine,
+		buggeroff: buggeroff,
+		ready:     make(chan struct{}),
+		procfs:    "/proc",
+		cgroupfs:  "/sys/fs/cgroup",
+	}
+	for _, opt := range opts {
+		opt(ww)
+	}
+	pf := whalewatcher.NewPortfolio(ww.pfopts...)
+	ww.readportfolio = pf
+	ww.writeportfolio = pf
+	if ww.checkpoint != "" {
+		// Show the last known portfolio as the "still" portfolio until we've
+		// synchronized with the container engine. If there is no (usable)
+		// checkpoint, then we simply start with an empty portfolio.
+		if still, err := whalewatcher.LoadCheckpoint(ww.checkpoint, ww.unpacker, ww.pfopts...); err == nil {
+			ww.readportfolio = still
+		}
 	}
 	ww.closeReady = sync.OnceFunc(func() { close(ww.ready) })
 	return ww
@@ -182,6 +228,7 @@ func (ww *watcher) Client() any { return ww.engine.Client() }
 // Close cleans up and release any underlying engine client resources, if
 // necessary. Doesn't care when called multiple times.
 func (ww *watcher) Close() {
+	ww.saveCheckpoint()
 	ww.eventchmux.Lock()
 	defer ww.eventchmux.Unlock()
 	ww.engine.Close()
@@ -215,7 +262,7 @@ func (ww *watcher) Watch(ctx context.Context) error {
 		// go "live" immediately.
 		ww.pfmux.Lock()
 		if ww.writeportfolio.ContainerTotal() != 0 {
-			ww.writeportfolio = whalewatcher.NewPortfolio()
+			ww.writeportfolio = whalewatcher.NewPortfolio(ww.pfopts...)
 		}
 		if ww.readportfolio.ContainerTotal() == 0 {
 			ww.readportfolio = ww.writeportfolio
@@ -250,11 +297,7 @@ func (ww *watcher) Watch(ctx context.Context) error {
 				listerr <- err
 				return
 			}
-			// Bring the synchronized portfolio "online" so that object users
-			// can now see the current portfolio and not the "still" portfolio.
-			ww.pfmux.Lock()
-			ww.readportfolio = ww.writeportfolio
-			ww.pfmux.Unlock()
+			ww.saveCheckpoint()
 		}()
 		// Permanently receive and process container lifecycle-related events,
 		// while at first there is a concurrent list operation also taking
@@ -285,11 +328,15 @@ func (ww *watcher) Watch(ctx context.Context) error {
 				case engineclient.ContainerStarted:
 					ww.born(ctx, ev.ID)
 				case engineclient.ContainerExited:
-					ww.demised(ev.ID, ev.Project)
+					ww.demised(ev.ID, ev.Project, ev.Exit)
 				case engineclient.ContainerPaused:
 					ww.paused(ev.ID, ev.Project, true)
 				case engineclient.ContainerUnpaused:
 					ww.paused(ev.ID, ev.Project, false)
+				case engineclient.ContainerHealthChanged:
+					ww.healthChanged(ev.ID, ev.Project, ev.Health)
+				default:
+					ww.informed(ev)
 				}
 			}
 		}
@@ -298,16 +345,22 @@ func (ww *watcher) Watch(ctx context.Context) error {
 
 // notify sends events to all registered lifecycle event channels.
 func (ww *watcher) notify(evt engineclient.ContainerEventType, cntr *whalewatcher.Container) {
-	if cntr == nil {
+	ww.send(ContainerEvent{
+		Type:      evt,
+		Container: cntr,
+	})
+}
+
+// send the specified event to all registered lifecycle event channels, unless
+// the event lacks its container.
+func (ww *watcher) send(ev ContainerEvent) {
+	if ev.Container == nil {
 		return
 	}
 	ww.eventchmux.Lock()
 	defer ww.eventchmux.Unlock()
 	for _, evs := range ww.eventchs {
-		evs <- ContainerEvent{
-			Type:      evt,
-			Container: cntr,
-		}
+		evs <- ev
 	}
 }
 
@@ -319,6 +372,7 @@ func (ww *watcher) notify(evt engineclient.ContainerEventType, cntr *whalewatche
 func (ww *watcher) born(ctx context.Context, id string) {
 	cntr, err := ww.engine.Inspect(ctx, id)
 	if err == nil {
+		ww.discover(cntr)
 		// The portfolio already properly handles concurrency operations, so we
 		// don't need to take any special care here. However, as we're
 		// potentially juggling portfolios around while resynchronizing after
@@ -337,8 +391,10 @@ func (ww *watcher) born(ctx context.Context, id string) {
 // from our container portfolio, ensuring it won't pop up again due to an
 // overlapping list scan. In case the project name isn't known (such as with the
 // containerd engine), the reserved "name" engineclient.ProjectUnknown can be
-// passed in and it will be derived automatically.
-func (ww *watcher) demised(id string, projectname string) {
+// passed in and it will be derived automatically. The optional exit
+// information gets passed on to the event consumers; if it lacks the runtime,
+// the runtime is derived from the container's start time, if known.
+func (ww *watcher) demised(id string, projectname string, exit *engineclient.ExitInfo) {
 	// The "event gate" does not only serializes access to the shared state
 	// between the container lifecycle event handler and the container listing
 	// one-shot go routine, it also serializes container termination lifecycle
@@ -357,6 +413,7 @@ func (ww *watcher) demised(id string, projectname string) {
 		ww.bluenorwegians = append(ww.bluenorwegians, id)
 	}
 	ww.pauses.Remove(id) // ensure to remove any pending (un)pause state update.
+	ww.healths.Remove(id)
 	ww.eventgate.Unlock()
 	ww.pfmux.RLock()
 	pf := ww.writeportfolio
@@ -372,7 +429,37 @@ func (ww *watcher) demised(id string, projectname string) {
 		projectname = container.Project
 	}
 	cntr := pf.Remove(id, projectname)
-	ww.notify(engineclient.ContainerExited, cntr)
+	if cntr != nil && exit != nil && exit.Runtime == 0 &&
+		!exit.ExitedAt.IsZero() && !cntr.StartedAt.IsZero() {
+		// Don't modify the engine client's exit information in place.
+		withruntime := *exit
+		withruntime.Runtime = exit.ExitedAt.Sub(cntr.StartedAt)
+		exit = &withruntime
+	}
+	ww.send(ContainerEvent{
+		Type:      engineclient.ContainerExited,
+		Container: cntr,
+		Exit:      exit,
+	})
+}
+
+// informed passes an informational container lifecycle event on to all
+// registered lifecycle event channels, without changing the portfolio. As
+// informational events usually concern containers that aren't alive (yet or
+// anymore), such as created or removed containers, the event then carries only
+// a container description with the container's ID and its project, if known.
+func (ww *watcher) informed(ev engineclient.ContainerEvent) {
+	ww.pfmux.RLock()
+	pf := ww.writeportfolio
+	ww.pfmux.RUnlock()
+	cntr := pf.Container(ev.ID)
+	if cntr == nil {
+		cntr = &whalewatcher.Container{ID: ev.ID}
+		if ev.Project != engineclient.ProjectUnknown {
+			cntr.Project = ev.Project
+		}
+	}
+	ww.notify(ev.Type, cntr)
 }
 
 // paused either updates a container's paused state or schedules for a later
@@ -410,14 +497,36 @@ func (ww *watcher) paused(id string, projectname string, paused bool) {
 		}
 		projectname = container.Project
 	}
-	if proj := pf.Project(projectname); proj != nil {
-		cntr := proj.SetPaused(id, paused)
-		if paused {
-			ww.notify(engineclient.ContainerPaused, cntr)
-		} else {
-			ww.notify(engineclient.ContainerUnpaused, cntr)
+	cntr := pf.SetPaused(id, projectname, paused)
+	if paused {
+		ww.notify(engineclient.ContainerPaused, cntr)
+	} else {
+		ww.notify(engineclient.ContainerUnpaused, cntr)
+	}
+}
+
+// healthChanged either updates a container's health status or schedules for a
+// later status update in case a container listing is in progress, similar to
+// [watcher.paused].
+func (ww *watcher) healthChanged(id string, projectname string, health string) {
+	ww.eventgate.Lock()
+	if ww.listinprogress {
+		ww.healths.Add(id, health)
+		ww.eventgate.Unlock()
+		return
+	}
+	ww.eventgate.Unlock()
+	ww.pfmux.RLock()
+	pf := ww.writeportfolio
+	ww.pfmux.RUnlock()
+	if projectname == engineclient.ProjectUnknown {
+		container := pf.Container(id)
+		if container == nil {
+			return
 		}
+		projectname = container.Project
 	}
+	ww.notify(engineclient.ContainerHealthChanged, pf.SetHealth(id, projectname, health))
 }
 
 // list scans for currently alive and kicking containers and then adds the
@@ -449,8 +558,16 @@ func (ww *watcher) list(ctx context.Context) error {
 	defer func() {
 		ww.bluenorwegians = []string{}
 		ww.pauses = pendingPauseStates{}
+		ww.healths = pendingHealthStates{}
 		ww.listinprogress = false // not strictly necessary here, but anywhere within the gated zone.
 		ww.eventgate.Unlock()
+		// Bring the synchronized portfolio "online" so that object users can
+		// now see the current portfolio and not the "still" portfolio; and do
+		// so before signalling readiness.
+		ww.pfmux.Lock()
+		ww.readportfolio = ww.writeportfolio
+		ww.live = true
+		ww.pfmux.Unlock()
 		ww.closeReady()
 	}()
 	ww.pfmux.RLock()
@@ -465,6 +582,7 @@ func (ww *watcher) list(ctx context.Context) error {
 		// portfolio; this is a "quick" operation without any trips to the
 		// container engine (we already did the "slow" and time-consuming bits
 		// before, such as inspecting the vontainer details).
+		ww.discover(alive)
 		if !pf.Add(alive) {
 			continue
 		}
@@ -480,16 +598,38 @@ func (ww *watcher) list(ctx context.Context) error {
 	// Note: we're still under the eventgate lock.
 	for _, pause := range ww.pauses {
 		if container := pf.Container(pause.ID); container != nil {
-			if project := pf.Project(container.Project); project != nil {
-				cntr := project.SetPaused(pause.ID, pause.Paused)
-				if pause.Paused {
-					ww.notify(engineclient.ContainerPaused, cntr)
-				} else {
-					ww.notify(engineclient.ContainerUnpaused, cntr)
-				}
+			cntr := pf.SetPaused(pause.ID, container.Project, pause.Paused)
+			if pause.Paused {
+				ww.notify(engineclient.ContainerPaused, cntr)
+			} else {
+				ww.notify(engineclient.ContainerUnpaused, cntr)
 			}
 		}
 	}
+	// Similarly, play back any pending health status changes.
+	for _, health := range ww.healths {
+		if container := pf.Container(health.ID); container != nil {
+			ww.notify(engineclient.ContainerHealthChanged,
+				pf.SetHealth(health.ID, container.Project, health.Health))
+		}
+	}
 	// Tumble into defer'red clearing the list of dead parrots and carrying on.
 	return nil
 }
+
+// saveCheckpoint saves the current read portfolio to the checkpoint file, if
+// configured, but only after the watcher has synchronized with its container
+// engine at least once. Failing to save a checkpoint is not fatal, as the
+// checkpoint is just a hint to speed up the next start.
+func (ww *watcher) saveCheckpoint() {
+	if ww.checkpoint == "" {
+		return
+	}
+	ww.pfmux.RLock()
+	pf, live := ww.readportfolio, ww.live
+	ww.pfmux.RUnlock()
+	if !live {
+		return
+	}
+	_ = whalewatcher.SaveCheckpoint(ww.checkpoint, pf)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cenkalti/backoff/v4"
 
+	"github.com/thediveo/whalewatcher/v2"
 	"github.com/thediveo/whalewatcher/v2/engineclient"
 	"github.com/thediveo/whalewatcher/v2/engineclient/moby"
 	"github.com/thediveo/whalewatcher/v2/test/mockingmoby"
@@ -155,7 +156,7 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 		ww.born(context.Background(), mockingMoby.ID)
 		Expect(ww.Portfolio().Project("").ContainerNames()).To(ConsistOf(mockingMoby.Name))
 
-		ww.demised(mockingMoby.ID, "")
+		ww.demised(mockingMoby.ID, "", nil)
 		Eventually(evs).Should(Receive(And(
 			HaveField("Type", engineclient.ContainerExited),
 			HaveField("Container", Not(BeNil())),
@@ -167,15 +168,38 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 		mm.AddContainer(porosePorpoise)
 
 		// Silently ignore events for non-existing container
-		ww.demised("notorious_nirvana", engineclient.ProjectUnknown)
+		ww.demised("notorious_nirvana", engineclient.ProjectUnknown, nil)
 
 		ww.born(context.Background(), porosePorpoise.ID)
 		Expect(ww.Portfolio().Project("porose").ContainerNames()).To(ConsistOf(porosePorpoise.Name))
 
-		ww.demised(porosePorpoise.ID, engineclient.ProjectUnknown)
+		ww.demised(porosePorpoise.ID, engineclient.ProjectUnknown, nil)
 		Expect(ww.Portfolio().Project("porose")).To(BeNil())
 	})
 
+	It("passes on exit information, deriving the runtime", func() {
+		startedAt := time.Date(2026, 10, 16, 12, 34, 56, 0, time.UTC)
+		oldMoby := mockingMoby
+		oldMoby.StartedAt = startedAt
+		mm.AddContainer(oldMoby)
+
+		evs := ww.Events()
+		ww.born(context.Background(), oldMoby.ID)
+		Expect(ww.Portfolio().Container(oldMoby.ID).StartedAt).To(BeTemporally("==", startedAt))
+
+		exit := &engineclient.ExitInfo{
+			ExitCode: 42,
+			ExitedAt: startedAt.Add(time.Hour),
+		}
+		ww.demised(oldMoby.ID, "", exit)
+		Eventually(evs).Should(Receive(And(
+			HaveField("Type", engineclient.ContainerExited),
+			HaveField("Exit.ExitCode", 42),
+			HaveField("Exit.Runtime", time.Hour),
+		)))
+		Expect(exit.Runtime).To(BeZero())
+	})
+
 	It("doesn't list zombies", func() {
 		// Prime mocked moby and ensure that we find all containers in our
 		// portfolio, so we know the simple case works.
@@ -193,7 +217,7 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 			mockingmoby.ContainerListPost,
 			func(mockingmoby.HookKey) error {
 				mm.RemoveContainer(furiousFuruncle.Name)
-				ww.demised(furiousFuruncle.ID, "")
+				ww.demised(furiousFuruncle.ID, "", nil)
 				return nil
 			}))).To(Succeed())
 		Eventually(evs).Should(Receive(And(
@@ -236,6 +260,41 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 		Expect(ww.Portfolio().Project("").Container(furiousFuruncle.ID).Paused).To(BeFalse())
 	})
 
+	It("correctly states health status while listing", func() {
+		mm.AddContainer(furiousFuruncle)
+
+		// health status changes during a list should be queued and properly
+		// handled later.
+		evs := ww.Events()
+		Expect(ww.list(mockingmoby.WithHook(
+			context.Background(),
+			mockingmoby.ContainerListPost,
+			func(mockingmoby.HookKey) error {
+				ww.healthChanged(furiousFuruncle.ID, "", whalewatcher.HealthStarting)
+				ww.healthChanged(furiousFuruncle.ID, "", whalewatcher.HealthUnhealthy)
+				return nil
+			}))).To(Succeed())
+		Eventually(evs).Should(Receive(HaveField("Type", engineclient.ContainerStarted)))
+		Eventually(evs).Should(Receive(And(
+			HaveField("Type", engineclient.ContainerHealthChanged),
+			HaveField("Container.Health", whalewatcher.HealthUnhealthy),
+		)))
+		Expect(evs).NotTo(Receive())
+
+		// a later health status change should be propagated "immediately".
+		ww.healthChanged(furiousFuruncle.ID, engineclient.ProjectUnknown, whalewatcher.HealthHealthy)
+		Eventually(evs).Should(Receive(And(
+			HaveField("Type", engineclient.ContainerHealthChanged),
+			HaveField("Container.Health", whalewatcher.HealthHealthy),
+		)))
+		Expect(ww.Portfolio().Container(furiousFuruncle.ID).Health).To(Equal(whalewatcher.HealthHealthy))
+
+		// silently ignore status changes of unknown containers.
+		ww.healthChanged("notorious_nirvana", engineclient.ProjectUnknown, whalewatcher.HealthHealthy)
+		ww.healthChanged("notorious_nirvana", "", whalewatcher.HealthHealthy)
+		Consistently(evs).ShouldNot(Receive())
+	})
+
 	It("correctly drops states pausing state for dying container while listing", func() {
 		// Prime mocked moby and ensure that we find all containers in our
 		// portfolio, so we know the simple case works.
@@ -250,7 +309,7 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 				mm.PauseContainer(furiousFuruncle.ID)
 				ww.paused(furiousFuruncle.ID, "", true)
 				mm.RemoveContainer(furiousFuruncle.ID)
-				ww.demised(furiousFuruncle.ID, "")
+				ww.demised(furiousFuruncle.ID, "", nil)
 				mm.AddContainer(furiousFuruncle)
 				ww.born(context.Background(), furiousFuruncle.ID)
 				return nil
@@ -274,7 +333,7 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 				mm.PauseContainer(furiousFuruncle.ID)
 				ww.paused(furiousFuruncle.ID, "", true)
 				mm.RemoveContainer(furiousFuruncle.ID)
-				ww.demised(furiousFuruncle.ID, "")
+				ww.demised(furiousFuruncle.ID, "", nil)
 				mm.AddContainer(furiousFuruncle)
 				ww.born(context.Background(), furiousFuruncle.ID)
 				ww.paused(furiousFuruncle.ID, "", true)
@@ -295,6 +354,48 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 		Expect(ww.Portfolio().Project("").ContainerNames()).To(BeEmpty())
 	})
 
+	It("passes on informational events without changing the portfolio", func() {
+		mm.AddContainer(porosePorpoise)
+		ww.born(context.Background(), porosePorpoise.ID)
+
+		evs := ww.Events()
+		ww.informed(engineclient.ContainerEvent{
+			Type:    engineclient.ContainerKilled,
+			ID:      porosePorpoise.ID,
+			Project: engineclient.ProjectUnknown,
+		})
+		Eventually(evs).Should(Receive(And(
+			HaveField("Type", engineclient.ContainerKilled),
+			HaveField("Container.Name", porosePorpoise.Name),
+			HaveField("Container.Project", "porose"),
+		)))
+
+		ww.informed(engineclient.ContainerEvent{
+			Type:    engineclient.ContainerRemoved,
+			ID:      "notorious_nirvana",
+			Project: "nirvana",
+		})
+		Eventually(evs).Should(Receive(And(
+			HaveField("Type", engineclient.ContainerRemoved),
+			HaveField("Container.ID", "notorious_nirvana"),
+			HaveField("Container.Project", "nirvana"),
+		)))
+
+		ww.informed(engineclient.ContainerEvent{
+			Type:    engineclient.ContainerCreated,
+			ID:      "notorious_nirvana",
+			Project: engineclient.ProjectUnknown,
+		})
+		Eventually(evs).Should(Receive(And(
+			HaveField("Type", engineclient.ContainerCreated),
+			HaveField("Container.ID", "notorious_nirvana"),
+			HaveField("Container.Project", ""),
+		)))
+
+		Expect(ww.Portfolio().ContainerTotal()).To(Equal(1))
+		Expect(ww.Portfolio().Project("porose").ContainerNames()).To(ConsistOf(porosePorpoise.Name))
+	})
+
 	It("binge watches", func() {
 		mm.AddContainer(mockingMoby)
 
@@ -381,3 +482,18 @@ var _ = Describe("watcher (of whales, not: Wales)", func() {
 	})
 
 })
+
+var _ = Describe("watcher from engine URL", func() {
+
+	It("opens watchers from engine URLs", func() {
+		ww, err := Open("docker:///run/foo.sock?pid=42", nil, WithNamespaces())
+		Expect(err).NotTo(HaveOccurred())
+		defer ww.Close()
+		Expect(ww.Type()).To(Equal(moby.Type))
+		Expect(ww.API()).To(Equal("unix:///run/foo.sock"))
+		Expect(ww.PID()).To(Equal(42))
+
+		Expect(Open("whale:///run/foo.sock", nil)).Error().To(HaveOccurred())
+	})
+
+})